@@ -11,6 +11,8 @@ type MessageBroker interface {
 	Subscribe(ctx context.Context, topic string) (*protobuf.Status, error)
 	// SubscribeAsync is used to subscribe to a topic and store the message with handler function
 	SubscribeAsync(request SubscribeAsyncRequest)
+	// Close releases the connections of the broker after delivering the pending messages
+	Close() error
 }
 
 type SubscribeAsyncRequest struct {
@@ -19,4 +21,6 @@ type SubscribeAsyncRequest struct {
 	Set          func(ctx context.Context, key, value string, exp time.Duration) error
 	Get          func(ctx context.Context, key string) (string, error)
 	GetSetMember func(ctx context.Context, key string) ([]string, error)
+	Delete       func(ctx context.Context, keys ...string) error
+	Notify       func(status *protobuf.Status)
 }
@@ -0,0 +1,67 @@
+package broker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/daffarg/distributed-cascading-cb/protobuf"
+)
+
+// minDeliveryTTL is the minimum time a status ID is remembered, for the statuses without a timeout
+const minDeliveryTTL = time.Minute
+
+// deliveries remembers the events handled by each broker for each endpoint until the statuses expire, so a status
+// delivered again, to several subscriptions of its topic or through several paths of the cascade is only handled
+// once by a sidecar
+var deliveries = &deliveredStatuses{seen: make(map[deliveredStatus]time.Time)}
+
+type deliveredStatus struct {
+	broker   MessageBroker
+	eventID  string
+	endpoint string
+}
+
+type deliveredStatuses struct {
+	mu        sync.Mutex
+	seen      map[deliveredStatus]time.Time
+	lastSweep time.Time
+}
+
+// isFirstDelivery reports whether the event of the status is handled for the first time by the broker for its endpoint.
+// Statuses of version 0 have no ID and are always handled.
+func isFirstDelivery(b MessageBroker, msg *protobuf.Status) bool {
+	eventID := EventID(msg)
+	if eventID == "" {
+		return true
+	}
+
+	now := time.Now()
+	timestamp, _ := time.Parse(time.RFC3339, msg.Timestamp)
+	expiry := timestamp.Add(time.Duration(msg.Timeout) * time.Second)
+	if minExpiry := now.Add(minDeliveryTTL); expiry.Before(minExpiry) {
+		expiry = minExpiry
+	}
+
+	return deliveries.add(deliveredStatus{broker: b, eventID: eventID, endpoint: msg.Endpoint}, expiry, now)
+}
+
+func (d *deliveredStatuses) add(status deliveredStatus, expiry, now time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if now.Sub(d.lastSweep) >= minDeliveryTTL {
+		for s, exp := range d.seen {
+			if !now.Before(exp) {
+				delete(d.seen, s)
+			}
+		}
+		d.lastSweep = now
+	}
+
+	if exp, ok := d.seen[status]; ok && now.Before(exp) {
+		return false
+	}
+	d.seen[status] = expiry
+
+	return true
+}
@@ -0,0 +1,85 @@
+package broker
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/daffarg/distributed-cascading-cb/protobuf"
+	"github.com/daffarg/distributed-cascading-cb/util"
+)
+
+// StatusVersion is the schema version of the statuses published by this sidecar.
+// Statuses of older sidecars have version 0 and carry none of the envelope fields.
+const StatusVersion = 1
+
+// Origin returns the ID of this sidecar set in the statuses it publishes, CB_SIDECAR_ID or <SERVICE_NAME>/<hostname>
+var Origin = sync.OnceValue(func() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	return util.GetEnv("CB_SIDECAR_ID", fmt.Sprintf("%s/%s", os.Getenv("SERVICE_NAME"), hostname))
+})
+
+// NewStatus returns a status of the endpoint caused by a circuit breaker of this sidecar
+func NewStatus(endpoint, status string, timeout time.Duration) *protobuf.Status {
+	messageID := newMessageID()
+	return &protobuf.Status{
+		Endpoint:          endpoint,
+		Status:            status,
+		Timeout:           uint32(timeout.Seconds()),
+		Timestamp:         time.Now().Format(time.RFC3339),
+		Version:           StatusVersion,
+		MessageId:         messageID,
+		Origin:            Origin(),
+		RootCauseEndpoint: endpoint,
+		EventId:           messageID,
+	}
+}
+
+// CascadeStatus returns the status of an endpoint requiring the endpoint of cause, one hop further from the root cause
+func CascadeStatus(cause *protobuf.Status, endpoint string, timeout time.Duration) *protobuf.Status {
+	msg := NewStatus(endpoint, cause.Status, timeout)
+	msg.RootCauseEndpoint = RootCauseEndpoint(cause)
+	msg.EventId = EventID(cause)
+	msg.HopCount = cause.HopCount + 1
+	msg.Reason = cause.Reason
+	msg.Path = append(slices.Clone(cause.Path), cause.Endpoint)
+
+	return msg
+}
+
+// IsVisited reports whether the cascade of the status already went through the endpoint
+func IsVisited(msg *protobuf.Status, endpoint string) bool {
+	return endpoint == msg.Endpoint || slices.Contains(msg.Path, endpoint)
+}
+
+// RootCauseEndpoint returns the endpoint whose circuit breaker caused the status,
+// which is the endpoint of the status itself for a version 0 status
+func RootCauseEndpoint(msg *protobuf.Status) string {
+	if msg.RootCauseEndpoint != "" {
+		return msg.RootCauseEndpoint
+	}
+	return msg.Endpoint
+}
+
+// EventID returns the ID of the event that started the cascade of the status, which is the message ID of a status
+// published before the event IDs were kept across hops
+func EventID(msg *protobuf.Status) string {
+	if msg.EventId != "" {
+		return msg.EventId
+	}
+	return msg.MessageId
+}
+
+func newMessageID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,57 @@
+package broker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/daffarg/distributed-cascading-cb/protobuf"
+)
+
+func TestCascadeStatus(t *testing.T) {
+	reason := &protobuf.TripReason{Cause: "ready-to-trip", ConsecutiveFailures: 5, Threshold: 5}
+
+	tests := []struct {
+		name          string
+		cause         *protobuf.Status
+		wantRootCause string
+		wantHopCount  uint32
+		wantReason    *protobuf.TripReason
+	}{
+		{
+			name:          "Version_0_status",
+			cause:         &protobuf.Status{Endpoint: "GET:b/api", Status: "open", Timeout: 60, MessageId: "version-0"},
+			wantRootCause: "GET:b/api",
+			wantHopCount:  1,
+		},
+		{
+			name:          "Cascaded_status",
+			cause:         CascadeStatus(&protobuf.Status{Endpoint: "GET:c/api", Status: "open", Reason: reason}, "GET:b/api", time.Minute),
+			wantRootCause: "GET:c/api",
+			wantHopCount:  2,
+			wantReason:    reason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CascadeStatus(tt.cause, "GET:a/api", time.Minute)
+			if got.Endpoint != "GET:a/api" || got.Status != tt.cause.Status || got.Timeout != 60 {
+				t.Errorf("CascadeStatus() = %v, want an open status of GET:a/api for 60 seconds", got)
+			}
+			if got.Version != StatusVersion || got.MessageId == "" || got.MessageId == tt.cause.MessageId || got.Origin != Origin() {
+				t.Errorf("CascadeStatus() envelope = %v, want a new message of this sidecar", got)
+			}
+			if got.EventId == "" || got.EventId != EventID(tt.cause) {
+				t.Errorf("EventId = %v, want the event %v of the cause", got.EventId, EventID(tt.cause))
+			}
+			if got.RootCauseEndpoint != tt.wantRootCause {
+				t.Errorf("RootCauseEndpoint = %v, want %v", got.RootCauseEndpoint, tt.wantRootCause)
+			}
+			if got.HopCount != tt.wantHopCount {
+				t.Errorf("HopCount = %v, want %v", got.HopCount, tt.wantHopCount)
+			}
+			if got.Reason != tt.wantReason {
+				t.Errorf("Reason = %v, want %v", got.Reason, tt.wantReason)
+			}
+		})
+	}
+}
@@ -3,7 +3,6 @@ package kafka
 import (
 	"bufio"
 	"context"
-	"errors"
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"github.com/daffarg/distributed-cascading-cb/broker"
 	"github.com/daffarg/distributed-cascading-cb/config"
@@ -13,14 +12,61 @@ import (
 	"github.com/go-kit/log/level"
 	"google.golang.org/protobuf/proto"
 	"os"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 )
 
+// producer is the part of *kafka.Producer used by the broker
+type producer interface {
+	Produce(msg *kafka.Message, deliveryChan chan kafka.Event) error
+	Events() chan kafka.Event
+	Flush(timeoutMs int) int
+	Close()
+}
+
+// consumer is the part of *kafka.Consumer used by the shared consumer of the broker
+type consumer interface {
+	SubscribeTopics(topics []string, rebalanceCb kafka.RebalanceCb) error
+	Unsubscribe() error
+	Assign(partitions []kafka.TopicPartition) error
+	Poll(timeoutMs int) kafka.Event
+	CommitMessage(m *kafka.Message) ([]kafka.TopicPartition, error)
+	Close() error
+}
+
 type kafkaBroker struct {
 	config   kafka.ConfigMap
 	log      log.Logger
 	cbConfig *config.Config
+
+	// producer and admin are shared by every publish and subscription for the lifetime of the broker
+	producer producer
+	admin    *kafka.AdminClient
+	reports  chan struct{}
+
+	topicsMu          sync.Mutex
+	topics            map[string]bool
+	partitions        int
+	replicationFactor int
+
+	// statusTopic is the compacted topic keyed by endpoint topic holding every status when it is set,
+	// instead of one topic per endpoint. Every partition of it is assigned to the consumer of each replica
+	// from statusPartitions, the positions where loadStatuses stopped.
+	statusTopic      string
+	statusPartitions []kafka.TopicPartition
+	statusesMu       sync.RWMutex
+	statuses         map[string]*protobuf.Status
+
+	// consumer is shared by every SubscribeAsync, its messages are dispatched to the handlers of their topic
+	consumer        consumer
+	handlersMu      sync.Mutex
+	handlers        map[string]map[*broker.SubscribeAsyncRequest]struct{}
+	handlersChanged bool
+	stop            chan struct{}
+	consumed        chan struct{}
+	closeOnce       sync.Once
 }
 
 func NewKafkaBroker(log log.Logger, configPath string, cbConfig *config.Config) (broker.MessageBroker, error) {
@@ -47,29 +93,171 @@ func NewKafkaBroker(log log.Logger, configPath string, cbConfig *config.Config)
 		return nil, err
 	}
 
+	producerConfig := make(kafka.ConfigMap)
+	for k, v := range m {
+		producerConfig[k] = v
+	}
+
+	producer, err := kafka.NewProducer(&producerConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	admin, err := kafka.NewAdminClientFromProducer(producer)
+	if err != nil {
+		producer.Close()
+		return nil, err
+	}
+
 	m["group.id"] = os.Getenv("CB_CONSUMER_GROUP")
 
+	consumerConfig := make(kafka.ConfigMap)
+	for k, v := range m {
+		consumerConfig[k] = v
+	}
+	consumerConfig["auto.offset.reset"] = "latest"
+	consumerConfig["enable.auto.commit"] = "false"
+	consumerConfig["allow.auto.create.topics"] = "true"
+
+	consumer, err := kafka.NewConsumer(&consumerConfig)
+	if err != nil {
+		admin.Close()
+		producer.Close()
+		return nil, err
+	}
+
+	k := newKafkaBroker(log, m, cbConfig, producer, admin, consumer)
+
+	if k.statusTopic != "" {
+		err = k.loadStatuses()
+		if err != nil {
+			consumer.Close()
+			admin.Close()
+			producer.Close()
+			return nil, err
+		}
+
+		// the compacted topic is subscribed once for every endpoint
+		k.handlersChanged = true
+	}
+
+	go k.handleDeliveryReports()
+	go k.consume()
+
+	return k, nil
+}
+
+func newKafkaBroker(log log.Logger, configMap kafka.ConfigMap, cbConfig *config.Config, producer producer, admin *kafka.AdminClient, consumer consumer) *kafkaBroker {
 	return &kafkaBroker{
-		config:   m,
-		log:      log,
-		cbConfig: cbConfig,
-	}, nil
+		config:            configMap,
+		log:               log,
+		cbConfig:          cbConfig,
+		producer:          producer,
+		admin:             admin,
+		reports:           make(chan struct{}),
+		topics:            make(map[string]bool),
+		partitions:        util.GetIntEnv("KAFKA_TOPIC_PARTITIONS", 1),
+		replicationFactor: util.GetIntEnv("KAFKA_TOPIC_REPLICATION_FACTOR", 3),
+		statusTopic:       os.Getenv("KAFKA_STATUS_TOPIC"),
+		statuses:          make(map[string]*protobuf.Status),
+		consumer:          consumer,
+		handlers:          make(map[string]map[*broker.SubscribeAsyncRequest]struct{}),
+		stop:              make(chan struct{}),
+		consumed:          make(chan struct{}),
+	}
 }
 
 func (k *kafkaBroker) Publish(ctx context.Context, topic string, message *protobuf.Status) error {
-	adminClient, err := kafka.NewAdminClient(&k.config)
+	msgBuf, err := proto.Marshal(message)
 	if err != nil {
 		return err
 	}
-	defer adminClient.Close()
+
+	kafkaMsg := &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+		Value:          msgBuf,
+	}
+	if k.statusTopic != "" {
+		kafkaMsg.TopicPartition.Topic = &k.statusTopic
+		kafkaMsg.Key = []byte(topic)
+	} else {
+		err = k.createTopic(ctx, topic, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the delivery result is reported asynchronously to handleDeliveryReports
+	return k.producer.Produce(kafkaMsg, nil)
+}
+
+// Close stops the consumer, flushes the pending messages and closes the producer and the admin client
+func (k *kafkaBroker) Close() error {
+	// the broker may be closed more than once, only the first close stops it
+	k.closeOnce.Do(func() {
+		close(k.stop)
+		<-k.consumed
+
+		remaining := k.producer.Flush(util.GetIntEnv("KAFKA_FLUSH_TIMEOUT", 15000))
+		if remaining > 0 {
+			level.Warn(k.log).Log(
+				util.LogMessage, "kafka messages left undelivered on close",
+				util.LogResult, remaining,
+			)
+		}
+
+		k.admin.Close()
+		k.producer.Close()
+		<-k.reports
+	})
+
+	return nil
+}
+
+func (k *kafkaBroker) handleDeliveryReports() {
+	defer close(k.reports)
+
+	for e := range k.producer.Events() {
+		switch ev := e.(type) {
+		case *kafka.Message:
+			if ev.TopicPartition.Error != nil {
+				level.Error(k.log).Log(
+					util.LogMessage, "failed to deliver a kafka message",
+					util.LogTopic, *ev.TopicPartition.Topic,
+					util.LogError, ev.TopicPartition.Error,
+				)
+			} else {
+				level.Info(k.log).Log(
+					util.LogMessage, "delivered a kafka message",
+					util.LogTopic, *ev.TopicPartition.Topic,
+				)
+			}
+		case kafka.Error:
+			level.Error(k.log).Log(
+				util.LogMessage, "kafka producer error",
+				util.LogError, ev,
+			)
+		}
+	}
+}
+
+// createTopic creates the topic with the configured partitions and replication factor unless it is already known to exist
+func (k *kafkaBroker) createTopic(ctx context.Context, topic string, topicConfig map[string]string) error {
+	k.topicsMu.Lock()
+	known := k.topics[topic]
+	k.topicsMu.Unlock()
+	if known {
+		return nil
+	}
 
 	topicSpecification := []kafka.TopicSpecification{{
 		Topic:             topic,
-		NumPartitions:     1,
-		ReplicationFactor: 3,
+		NumPartitions:     k.partitions,
+		ReplicationFactor: k.replicationFactor,
+		Config:            topicConfig,
 	}}
 
-	results, err := adminClient.CreateTopics(ctx, topicSpecification)
+	results, err := k.admin.CreateTopics(ctx, topicSpecification)
 	if err != nil {
 		return err
 	}
@@ -80,37 +268,31 @@ func (k *kafkaBroker) Publish(ctx context.Context, topic string, message *protob
 			util.LogTopic, result.Topic,
 			util.LogResult, result.Error,
 		)
-	}
-
-	p, _ := kafka.NewProducer(&k.config)
-
-	msgBuf, err := proto.Marshal(message)
-	if err != nil {
-		return err
-	}
 
-	err = p.Produce(&kafka.Message{
-		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
-		Value:          msgBuf,
-	}, nil)
-	if err != nil {
-		return err
+		code := result.Error.Code()
+		if code == kafka.ErrNoError || code == kafka.ErrTopicAlreadyExists {
+			k.topicsMu.Lock()
+			k.topics[result.Topic] = true
+			k.topicsMu.Unlock()
+		}
 	}
 
-	p.Flush(15 * 1000)
-	p.Close()
-
 	return nil
 }
 
 func (k *kafkaBroker) Subscribe(_ context.Context, topic string) (*protobuf.Status, error) {
-	adminClient, err := kafka.NewAdminClient(&k.config)
-	if err != nil {
-		return nil, err
+	if k.statusTopic != "" {
+		k.statusesMu.RLock()
+		defer k.statusesMu.RUnlock()
+
+		msg, ok := k.statuses[topic]
+		if !ok {
+			return nil, util.ErrUpdatedStatusNotFound
+		}
+		return proto.Clone(msg).(*protobuf.Status), nil
 	}
-	defer adminClient.Close()
 
-	metadata, err := adminClient.GetMetadata(&topic, false, util.GetIntEnv("KAFKA_GET_METADATA_TIMEOUT", 30000))
+	metadata, err := k.admin.GetMetadata(&topic, false, util.GetIntEnv("KAFKA_GET_METADATA_TIMEOUT", 30000))
 	if err != nil {
 		return nil, err
 	}
@@ -237,277 +419,342 @@ func (k *kafkaBroker) Subscribe(_ context.Context, topic string) (*protobuf.Stat
 	}
 }
 
+// SubscribeAsync registers the request as a handler of its topic on the shared consumer until the request context is done
 func (k *kafkaBroker) SubscribeAsync(request broker.SubscribeAsyncRequest) {
-	adminClient, err := kafka.NewAdminClient(&k.config)
-	if err != nil {
-		level.Error(k.log).Log(
-			util.LogMessage, "failed to get kafka admin client",
-			util.LogTopic, request.Topic,
-			util.LogError, err,
-		)
-	} else {
-		metadata, err := adminClient.GetMetadata(&request.Topic, false, util.GetIntEnv("KAFKA_GET_METADATA_TIMEOUT", 30000))
+	if k.statusTopic == "" {
+		err := k.createTopic(request.Ctx, request.Topic, nil)
 		if err != nil {
 			level.Error(k.log).Log(
-				util.LogMessage, "failed to get metadata of kafka topic",
+				util.LogMessage, "failed to create a new kafka topic",
 				util.LogTopic, request.Topic,
 				util.LogError, err,
 			)
 		}
+	}
 
-		level.Info(k.log).Log(
-			util.LogMessage, "metadata of kafka topic",
-			util.LogTopic, request.Topic,
-			util.LogMetadata, metadata,
-		)
+	handler := &request
+	k.addHandler(handler)
 
-		if len(metadata.Topics[request.Topic].Partitions) <= 0 { // determine if the topic not exists
-			level.Info(k.log).Log(
-				util.LogMessage, "kafka topic not found, will be creating the topic",
-				util.LogTopic, request.Topic,
-			)
+	level.Info(k.log).Log(
+		util.LogMessage, "subscribed to a kafka topic",
+		util.LogTopic, request.Topic,
+	)
+
+	select {
+	case <-request.Ctx.Done():
+	case <-k.stop:
+	}
+
+	k.removeHandler(handler)
+}
+
+// addHandler registers the handler of a topic, the subscription changes with the first handler of a topic
+// unless the compacted status topic is used
+func (k *kafkaBroker) addHandler(handler *broker.SubscribeAsyncRequest) {
+	k.handlersMu.Lock()
+	defer k.handlersMu.Unlock()
+
+	if k.handlers[handler.Topic] == nil {
+		k.handlers[handler.Topic] = make(map[*broker.SubscribeAsyncRequest]struct{})
+		// the status topic is subscribed for every endpoint, clearing the flag would drop its pending subscription
+		k.handlersChanged = k.handlersChanged || k.statusTopic == ""
+	}
+	k.handlers[handler.Topic][handler] = struct{}{}
+}
 
-			topicSpecification := []kafka.TopicSpecification{{
-				Topic:             request.Topic,
-				NumPartitions:     1,
-				ReplicationFactor: 3,
-			}}
+// removeHandler removes the handler of a topic, the subscription changes with the last handler of a topic
+// unless the compacted status topic is used
+func (k *kafkaBroker) removeHandler(handler *broker.SubscribeAsyncRequest) {
+	k.handlersMu.Lock()
+	defer k.handlersMu.Unlock()
 
-			results, err := adminClient.CreateTopics(request.Ctx, topicSpecification)
+	delete(k.handlers[handler.Topic], handler)
+	if len(k.handlers[handler.Topic]) == 0 {
+		delete(k.handlers, handler.Topic)
+		k.handlersChanged = k.handlersChanged || k.statusTopic == ""
+	}
+}
+
+// consume polls the shared consumer and dispatches every message to the handlers of its topic.
+// The subscription is only changed from here since it replaces the topic list of the consumer.
+func (k *kafkaBroker) consume() {
+	defer close(k.consumed)
+	defer k.consumer.Close()
+
+	var retryAt time.Time
+	for {
+		select {
+		case <-k.stop:
+			return
+		default:
+		}
+
+		if topics, changed := k.subscribedTopics(); changed && time.Now().After(retryAt) {
+			err := k.resubscribe(topics)
 			if err != nil {
-				level.Error(k.log).Log(
-					util.LogMessage, "failed to create a new kafka topic",
-					util.LogTopic, request.Topic,
+				level.Warn(k.log).Log(
+					util.LogMessage, "failed to subscribe to topics",
+					util.LogTopic, strings.Join(topics, ","),
 					util.LogError, err,
 				)
-			}
 
-			for _, result := range results {
-				level.Info(k.log).Log(
-					util.LogMessage, "result of kafka topic creation",
-					util.LogTopic, result.Topic,
-					util.LogResult, result.Error,
-				)
+				k.handlersMu.Lock()
+				k.handlersChanged = true
+				k.handlersMu.Unlock()
+				retryAt = time.Now().Add(time.Duration(util.GetIntEnv("RETRY_SUBSCRIBE_INTERVAL", 10)) * time.Second)
 			}
 		}
 
-		adminClient.Close()
+		ev := k.consumer.Poll(util.GetIntEnv("KAFKA_POLL_TIMEOUT", 100))
+		switch e := ev.(type) {
+		case *kafka.Message:
+			k.dispatch(e)
+		case kafka.Error:
+			level.Error(k.log).Log(
+				util.LogMessage, "failed to read a message from kafka",
+				util.LogError, e,
+			)
+		}
+	}
+}
+
+// subscribedTopics returns the topics having a handler and whether they changed since the last call
+func (k *kafkaBroker) subscribedTopics() ([]string, bool) {
+	k.handlersMu.Lock()
+	defer k.handlersMu.Unlock()
+
+	if !k.handlersChanged {
+		return nil, false
 	}
+	k.handlersChanged = false
 
-	k.config["auto.offset.reset"] = "latest"
-	k.config["enable.auto.commit"] = "false"
-	k.config["allow.auto.create.topics"] = "true"
+	if k.statusTopic != "" {
+		return []string{k.statusTopic}, true
+	}
 
-	consumer, _ := kafka.NewConsumer(&k.config)
+	topics := make([]string, 0, len(k.handlers))
+	for topic := range k.handlers {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
 
-	for {
-		err := consumer.SubscribeTopics([]string{request.Topic}, nil)
+	return topics, true
+}
+
+func (k *kafkaBroker) resubscribe(topics []string) error {
+	if k.statusTopic != "" {
+		// the partitions are assigned instead of being shared by the consumer group,
+		// otherwise the other replicas would keep serving a stale status from Subscribe
+		err := k.consumer.Assign(k.statusPartitions)
 		if err != nil {
-			level.Warn(k.log).Log(
-				util.LogMessage, "failed to subscribe to topic",
-				util.LogTopic, request.Topic,
-				util.LogError, err,
-			)
-			time.Sleep(time.Duration(util.GetIntEnv("RETRY_SUBSCRIBE_INTERVAL", 10)) * time.Second)
-		} else {
-			break
+			return err
 		}
+
+		level.Info(k.log).Log(
+			util.LogMessage, "assigned kafka partitions",
+			util.LogTopic, k.statusTopic,
+		)
+
+		return nil
+	}
+
+	if len(topics) == 0 {
+		return k.consumer.Unsubscribe()
+	}
+
+	err := k.consumer.SubscribeTopics(topics, nil)
+	if err != nil {
+		return err
 	}
-	defer consumer.Close()
 
 	level.Info(k.log).Log(
-		util.LogMessage, "subscribed to a kafka topic",
-		util.LogTopic, request.Topic,
+		util.LogMessage, "updated kafka subscription",
+		util.LogTopic, strings.Join(topics, ","),
 	)
 
-	for {
-		kafkaMsg, err := consumer.ReadMessage(-1)
-		if err != nil {
-			level.Error(k.log).Log(
-				util.LogMessage, "failed to read a message from kafka",
-				util.LogTopic, request.Topic,
-				util.LogError, err,
-			)
-		} else {
-			msg := &protobuf.Status{}
-			err = proto.Unmarshal(kafkaMsg.Value, msg)
-			if err != nil {
-				level.Error(k.log).Log(
-					util.LogMessage, "failed to unmarshal kafka message",
-					util.LogError, err,
-					util.LogTopic, request.Topic,
-				)
-				continue
-			}
+	return nil
+}
 
-			_, err = consumer.CommitMessage(kafkaMsg)
-			if err != nil {
-				level.Error(k.log).Log(
-					util.LogMessage, "failed to commit a kafka message",
-					util.LogError, err,
-					util.LogTopic, request.Topic,
-				)
-			} else {
-				level.Info(k.log).Log(
-					util.LogMessage, "received and committed a kafka message",
-					util.LogTopic, request.Topic,
-					util.LogStatus, msg,
-				)
-			}
+func (k *kafkaBroker) dispatch(kafkaMsg *kafka.Message) {
+	topic := *kafkaMsg.TopicPartition.Topic
+	if k.statusTopic != "" {
+		topic = string(kafkaMsg.Key)
+	}
 
-			timestamp, _ := time.Parse(time.RFC3339, msg.Timestamp)
-			expiredTime := timestamp.Add(time.Duration(msg.Timeout) * time.Second)
-			if time.Now().Before(expiredTime) {
-				timeout := expiredTime.Sub(time.Now()) * time.Second
-				isThereAlt := false
-				if alt, ok := k.cbConfig.AlternativeEndpoints[msg.Endpoint]; ok {
-					for _, ep := range alt.Alternatives {
-						endpointName := util.FormEndpointName(ep.Endpoint, ep.Method)
-						_, err := request.Get(context.Background(), util.FormEndpointStatusKey(endpointName))
-						if err != nil {
-							if errors.Is(err, util.ErrKeyNotFound) {
-								isThereAlt = true
-								break
-							} else {
-								level.Error(k.log).Log(
-									util.LogMessage, "failed to get endpoint status from db",
-									util.LogEndpoint, endpointName,
-									util.LogError, err,
-								)
-							}
-						}
-					}
-				}
+	msg, err := k.storeStatus(kafkaMsg)
+	switch {
+	case err != nil:
+		level.Error(k.log).Log(
+			util.LogMessage, "failed to unmarshal kafka message",
+			util.LogError, err,
+			util.LogTopic, topic,
+		)
+	case msg == nil:
+		// a tombstone only removes the status of the endpoint from the compacted topic
+	default:
+		level.Info(k.log).Log(
+			util.LogMessage, "received a kafka message",
+			util.LogTopic, topic,
+			util.LogStatus, msg,
+		)
 
-				if !isThereAlt {
-					go func() {
-						requiringEndpoints, err := request.GetSetMember(context.Background(), util.FormRequiringEndpointsKey(msg.Endpoint))
-						if err != nil {
-							level.Error(k.log).Log(
-								util.LogMessage, "failed to get requiring endpoints from db",
-								util.LogError, err,
-								util.LogCircuitBreakerEndpoint, msg.Endpoint,
-								util.LogCircuitBreakerNewStatus, msg.Status,
-							)
-
-							err := request.Set(context.Background(), util.FormEndpointStatusKey(msg.Endpoint), msg.Status, timeout)
-							if err != nil {
-								level.Error(k.log).Log(
-									util.LogMessage, "failed to set circuit breaker status to db",
-									util.LogError, err,
-									util.LogCircuitBreakerEndpoint, msg.Endpoint,
-									util.LogCircuitBreakerNewStatus, msg.Status,
-								)
-							}
-						} else {
-							for _, ep := range requiringEndpoints {
-								encodedTopic := util.EncodeTopic(ep)
-								message := &protobuf.Status{
-									Endpoint:  ep,
-									Status:    msg.Status,
-									Timeout:   uint32(util.GetIntEnv("CB_TIMEOUT", 60)),
-									Timestamp: time.Now().Format(time.RFC3339),
-								}
-								if err != nil {
-									level.Error(k.log).Log(
-										util.LogMessage, "failed to marshal circuit breaker status",
-										util.LogError, err,
-										util.LogCircuitBreakerEndpoint, ep,
-										util.LogCircuitBreakerNewStatus, msg.Status,
-									)
-								}
-
-								err = k.Publish(context.Background(), encodedTopic, message)
-								if err != nil {
-									level.Error(k.log).Log(
-										util.LogMessage, "failed to publish circuit breaker status",
-										util.LogError, err,
-										util.LogCircuitBreakerEndpoint, ep,
-										util.LogCircuitBreakerNewStatus, msg.Status,
-									)
-								} else {
-									level.Info(k.log).Log(
-										util.LogMessage, "published circuit breaker status",
-										util.LogStatus, message,
-									)
-								}
-
-								err := request.Set(context.Background(), util.FormEndpointStatusKey(ep), msg.Status, timeout)
-								if err != nil {
-									level.Error(k.log).Log(
-										util.LogMessage, "failed to set circuit breaker status to db",
-										util.LogError, err,
-										util.LogCircuitBreakerEndpoint, ep,
-										util.LogCircuitBreakerNewStatus, msg.Status,
-									)
-								}
-							}
-						}
-					}()
-				} else {
-					level.Info(k.log).Log(
-						util.LogMessage, "there are still alternative endpoints, only publishing the endpoint not its requirings",
-						util.LogCircuitBreakerEndpoint, msg.Endpoint,
-						util.LogCircuitBreakerNewStatus, msg.Status,
-					)
+		k.handlersMu.Lock()
+		requests := make([]broker.SubscribeAsyncRequest, 0, len(k.handlers[topic]))
+		for handler := range k.handlers[topic] {
+			requests = append(requests, *handler)
+		}
+		k.handlersMu.Unlock()
 
-					err := request.Set(context.Background(), util.FormEndpointStatusKey(msg.Endpoint), msg.Status, timeout)
-					if err != nil {
-						level.Error(k.log).Log(
-							util.LogMessage, "failed to set circuit breaker status to db",
-							util.LogError, err,
-							util.LogCircuitBreakerEndpoint, msg.Endpoint,
-							util.LogCircuitBreakerNewStatus, msg.Status,
-						)
-					}
-
-					encodedTopic := util.EncodeTopic(msg.Endpoint)
-					message := &protobuf.Status{
-						Endpoint:  msg.Endpoint,
-						Status:    msg.Status,
-						Timeout:   uint32(util.GetIntEnv("CB_TIMEOUT", 60)),
-						Timestamp: time.Now().Format(time.RFC3339),
-					}
-					if err != nil {
-						level.Error(k.log).Log(
-							util.LogMessage, "failed to marshal circuit breaker status",
-							util.LogError, err,
-							util.LogCircuitBreakerEndpoint, msg.Endpoint,
-							util.LogCircuitBreakerNewStatus, msg.Status,
-						)
-					}
-
-					err = k.Publish(context.Background(), encodedTopic, message)
-					if err != nil {
-						level.Error(k.log).Log(
-							util.LogMessage, "failed to publish circuit breaker status",
-							util.LogError, err,
-							util.LogCircuitBreakerEndpoint, msg.Endpoint,
-							util.LogCircuitBreakerNewStatus, msg.Status,
-						)
-					} else {
-						level.Info(k.log).Log(
-							util.LogMessage, "published circuit breaker status",
-							util.LogStatus, message,
-						)
-					}
-				}
-			}
+		for _, request := range requests {
+			broker.HandleStatus(k.log, k, k.cbConfig, request, msg)
+		}
+	}
+
+	if k.statusTopic != "" {
+		// the assigned status topic is not consumed as a group, so there is no offset to commit
+		return
+	}
+
+	_, err = k.consumer.CommitMessage(kafkaMsg)
+	if err != nil {
+		level.Error(k.log).Log(
+			util.LogMessage, "failed to commit a kafka message",
+			util.LogError, err,
+			util.LogTopic, topic,
+		)
+	}
+}
+
+// storeStatus unmarshals the status of the message and keeps it as the latest status of its key when
+// the compacted status topic is used. It returns a nil status for a tombstone.
+func (k *kafkaBroker) storeStatus(kafkaMsg *kafka.Message) (*protobuf.Status, error) {
+	if k.statusTopic != "" && kafkaMsg.Value == nil {
+		k.statusesMu.Lock()
+		delete(k.statuses, string(kafkaMsg.Key))
+		k.statusesMu.Unlock()
+		return nil, nil
+	}
+
+	msg := &protobuf.Status{}
+	err := proto.Unmarshal(kafkaMsg.Value, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	if k.statusTopic != "" {
+		k.statusesMu.Lock()
+		k.statuses[string(kafkaMsg.Key)] = msg
+		k.statusesMu.Unlock()
+	}
+
+	return msg, nil
+}
+
+// loadStatuses creates the compacted status topic and reads it from the beginning up to its end,
+// so a starting sidecar knows the latest status of every endpoint. Loading stops at the end of every
+// partition or when the timeout is reached, the rest of the topic is then read by consume.
+func (k *kafkaBroker) loadStatuses() error {
+	timeout := util.GetIntEnv("KAFKA_GET_METADATA_TIMEOUT", 30000)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Millisecond)
+	defer cancel()
+
+	err := k.createTopic(ctx, k.statusTopic, map[string]string{"cleanup.policy": "compact"})
+	if err != nil {
+		return err
+	}
+
+	metadata, err := k.admin.GetMetadata(&k.statusTopic, false, timeout)
+	if err != nil {
+		return err
+	}
+
+	consumerConfig := make(kafka.ConfigMap)
+	for k, v := range k.config {
+		consumerConfig[k] = v
+	}
+	consumerConfig["enable.auto.commit"] = false
+	consumerConfig["enable.partition.eof"] = true
+
+	consumer, err := kafka.NewConsumer(&consumerConfig)
+	if err != nil {
+		return err
+	}
+	defer consumer.Close()
 
-			_, err = consumer.CommitMessage(kafkaMsg)
+	offsets := make(map[int32]kafka.Offset)
+	for _, partition := range metadata.Topics[k.statusTopic].Partitions {
+		offsets[partition.ID] = kafka.OffsetBeginning
+	}
+
+	k.statusPartitions = statusPartitions(k.statusTopic, offsets)
+	err = consumer.Assign(k.statusPartitions)
+	if err != nil {
+		return err
+	}
+
+	loading := make(map[int32]bool, len(offsets))
+	for partition := range offsets {
+		loading[partition] = true
+	}
+
+	for len(loading) > 0 {
+		select {
+		case <-ctx.Done():
+			level.Warn(k.log).Log(
+				util.LogMessage, "timeout when loading statuses from the compacted kafka topic",
+				util.LogTopic, k.statusTopic,
+			)
+			k.statusPartitions = statusPartitions(k.statusTopic, offsets)
+			return nil
+		default:
+		}
+
+		switch e := consumer.Poll(util.GetIntEnv("KAFKA_POLL_TIMEOUT", 100)).(type) {
+		case *kafka.Message:
+			_, err = k.storeStatus(e)
 			if err != nil {
 				level.Error(k.log).Log(
-					util.LogMessage, "failed to commit a kafka message",
+					util.LogMessage, "failed to unmarshal kafka message",
 					util.LogError, err,
-					util.LogTopic, request.Topic,
-				)
-			} else {
-				level.Info(k.log).Log(
-					util.LogMessage, "received and committed a kafka message",
-					util.LogTopic, request.Topic,
-					util.LogStatus, msg,
+					util.LogTopic, string(e.Key),
 				)
 			}
+			offsets[e.TopicPartition.Partition] = e.TopicPartition.Offset + 1
+		case kafka.PartitionEOF:
+			offsets[e.Partition] = e.Offset
+			delete(loading, e.Partition)
+		case kafka.Error:
+			level.Warn(k.log).Log(
+				util.LogMessage, "failed to read a message from kafka",
+				util.LogError, e,
+				util.LogTopic, k.statusTopic,
+			)
 		}
 	}
+
+	k.statusPartitions = statusPartitions(k.statusTopic, offsets)
+
+	k.statusesMu.RLock()
+	level.Info(k.log).Log(
+		util.LogMessage, "loaded statuses from the compacted kafka topic",
+		util.LogTopic, k.statusTopic,
+		util.LogResult, len(k.statuses),
+	)
+	k.statusesMu.RUnlock()
+
+	return nil
+}
+
+// statusPartitions returns the partitions of the status topic starting at the given offsets
+func statusPartitions(topic string, offsets map[int32]kafka.Offset) []kafka.TopicPartition {
+	parts := make([]kafka.TopicPartition, 0, len(offsets))
+	for partition, offset := range offsets {
+		parts = append(parts, kafka.TopicPartition{Topic: &topic, Partition: partition, Offset: offset})
+	}
+	sort.Slice(parts, func(i, j int) bool {
+		return parts[i].Partition < parts[j].Partition
+	})
+
+	return parts
 }
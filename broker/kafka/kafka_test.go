@@ -0,0 +1,390 @@
+package kafka
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/daffarg/distributed-cascading-cb/broker"
+	"github.com/daffarg/distributed-cascading-cb/config"
+	"github.com/daffarg/distributed-cascading-cb/protobuf"
+	"github.com/daffarg/distributed-cascading-cb/util"
+	"github.com/go-kit/log"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeConsumer records the subscriptions and commits of the broker and returns the queued events from Poll
+type fakeConsumer struct {
+	mu            sync.Mutex
+	subscriptions [][]string
+	assignments   [][]kafka.TopicPartition
+	commits       int
+	events        chan kafka.Event
+}
+
+func newFakeConsumer() *fakeConsumer {
+	return &fakeConsumer{events: make(chan kafka.Event, 10)}
+}
+
+func (c *fakeConsumer) SubscribeTopics(topics []string, _ kafka.RebalanceCb) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.subscriptions = append(c.subscriptions, topics)
+	return nil
+}
+
+func (c *fakeConsumer) Unsubscribe() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.subscriptions = append(c.subscriptions, nil)
+	return nil
+}
+
+func (c *fakeConsumer) Assign(partitions []kafka.TopicPartition) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.assignments = append(c.assignments, partitions)
+	return nil
+}
+
+func (c *fakeConsumer) Poll(timeoutMs int) kafka.Event {
+	select {
+	case e := <-c.events:
+		return e
+	case <-time.After(time.Duration(timeoutMs) * time.Millisecond):
+		return nil
+	}
+}
+
+func (c *fakeConsumer) CommitMessage(_ *kafka.Message) ([]kafka.TopicPartition, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.commits++
+	return nil, nil
+}
+
+func (c *fakeConsumer) Close() error {
+	return nil
+}
+
+func (c *fakeConsumer) calls() ([][]string, [][]kafka.TopicPartition, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([][]string(nil), c.subscriptions...), append([][]kafka.TopicPartition(nil), c.assignments...), c.commits
+}
+
+func newHandler(topic string, notified chan<- string) *broker.SubscribeAsyncRequest {
+	return &broker.SubscribeAsyncRequest{
+		Ctx:   context.Background(),
+		Topic: topic,
+		Notify: func(status *protobuf.Status) {
+			notified <- topic
+		},
+		Get: func(ctx context.Context, key string) (string, error) {
+			return "", util.ErrKeyNotFound
+		},
+		Delete: func(ctx context.Context, keys ...string) error {
+			return nil
+		},
+		GetSetMember: func(ctx context.Context, key string) ([]string, error) {
+			return nil, nil
+		},
+	}
+}
+
+func statusMessage(t *testing.T, topic string, key string, status *protobuf.Status) *kafka.Message {
+	var value []byte
+	if status != nil {
+		var err error
+		value, err = proto.Marshal(status)
+		if err != nil {
+			t.Fatalf("proto.Marshal() error = %v", err)
+		}
+	}
+
+	kafkaMsg := &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic},
+		Value:          value,
+	}
+	if key != "" {
+		kafkaMsg.Key = []byte(key)
+	}
+	return kafkaMsg
+}
+
+func Test_kafkaBroker_subscribedTopics(t *testing.T) {
+	type step struct {
+		add         []string
+		remove      []string
+		wantTopics  []string
+		wantChanged bool
+	}
+
+	tests := []struct {
+		name        string
+		statusTopic string
+		steps       []step
+	}{
+		{
+			name: "Endpoint_topics",
+			steps: []step{
+				{add: []string{"a"}, wantTopics: []string{"a"}, wantChanged: true},
+				{add: []string{"b", "a"}, wantTopics: []string{"a", "b"}, wantChanged: true},
+				{add: []string{"a"}, wantChanged: false},
+				{remove: []string{"a"}, wantChanged: false},
+				{remove: []string{"a", "a"}, wantTopics: []string{"b"}, wantChanged: true},
+				{remove: []string{"b"}, wantTopics: []string{}, wantChanged: true},
+				{wantChanged: false},
+			},
+		},
+		{
+			name:        "Status_topic",
+			statusTopic: "statuses",
+			steps: []step{
+				{add: []string{"a"}, wantTopics: []string{"statuses"}, wantChanged: true},
+				{add: []string{"b"}, wantChanged: false},
+				{remove: []string{"a", "b"}, wantChanged: false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("KAFKA_STATUS_TOPIC", tt.statusTopic)
+			k := newKafkaBroker(log.NewNopLogger(), nil, config.NewConfig(), nil, nil, newFakeConsumer())
+			// the compacted status topic is subscribed once when the broker starts
+			k.handlersChanged = tt.statusTopic != ""
+
+			handlers := make(map[string][]*broker.SubscribeAsyncRequest)
+			for i, s := range tt.steps {
+				for _, topic := range s.add {
+					handler := newHandler(topic, nil)
+					handlers[topic] = append(handlers[topic], handler)
+					k.addHandler(handler)
+				}
+				for _, topic := range s.remove {
+					k.removeHandler(handlers[topic][0])
+					handlers[topic] = handlers[topic][1:]
+				}
+
+				topics, changed := k.subscribedTopics()
+				if changed != s.wantChanged || (changed && !reflect.DeepEqual(topics, s.wantTopics)) {
+					t.Errorf("step %d: subscribedTopics() = %v, %v, want %v, %v", i, topics, changed, s.wantTopics, s.wantChanged)
+				}
+			}
+		})
+	}
+}
+
+func Test_kafkaBroker_dispatch(t *testing.T) {
+	status := &protobuf.Status{Endpoint: "GET:localhost:8081/hello", Status: "closed"}
+
+	tests := []struct {
+		name         string
+		statusTopic  string
+		stored       map[string]*protobuf.Status
+		message      func(t *testing.T) *kafka.Message
+		wantNotified []string
+		wantStatuses []string
+		wantCommits  int
+	}{
+		{
+			name: "Endpoint_topic",
+			message: func(t *testing.T) *kafka.Message {
+				return statusMessage(t, "a", "", status)
+			},
+			wantNotified: []string{"a"},
+			wantStatuses: []string{},
+			wantCommits:  1,
+		},
+		{
+			name: "No_handler",
+			message: func(t *testing.T) *kafka.Message {
+				return statusMessage(t, "c", "", status)
+			},
+			wantStatuses: []string{},
+			wantCommits:  1,
+		},
+		{
+			name: "Invalid_message",
+			message: func(t *testing.T) *kafka.Message {
+				kafkaMsg := statusMessage(t, "a", "", nil)
+				kafkaMsg.Value = []byte{0xff}
+				return kafkaMsg
+			},
+			wantStatuses: []string{},
+			wantCommits:  1,
+		},
+		{
+			name:        "Status_topic",
+			statusTopic: "statuses",
+			message: func(t *testing.T) *kafka.Message {
+				return statusMessage(t, "statuses", "b", status)
+			},
+			wantNotified: []string{"b"},
+			wantStatuses: []string{"b"},
+		},
+		{
+			name:        "Tombstone",
+			statusTopic: "statuses",
+			stored:      map[string]*protobuf.Status{"a": status, "b": status},
+			message: func(t *testing.T) *kafka.Message {
+				return statusMessage(t, "statuses", "a", nil)
+			},
+			wantStatuses: []string{"b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("KAFKA_STATUS_TOPIC", tt.statusTopic)
+			c := newFakeConsumer()
+			k := newKafkaBroker(log.NewNopLogger(), nil, config.NewConfig(), nil, nil, c)
+			for key, s := range tt.stored {
+				k.statuses[key] = s
+			}
+
+			notified := make(chan string, 2)
+			k.addHandler(newHandler("a", notified))
+			k.addHandler(newHandler("b", notified))
+
+			k.dispatch(tt.message(t))
+			close(notified)
+
+			var gotNotified []string
+			for topic := range notified {
+				gotNotified = append(gotNotified, topic)
+			}
+			if !reflect.DeepEqual(gotNotified, tt.wantNotified) {
+				t.Errorf("dispatch() notified = %v, want %v", gotNotified, tt.wantNotified)
+			}
+
+			gotStatuses := make([]string, 0)
+			for key := range k.statuses {
+				gotStatuses = append(gotStatuses, key)
+			}
+			if !reflect.DeepEqual(gotStatuses, tt.wantStatuses) {
+				t.Errorf("dispatch() statuses = %v, want %v", gotStatuses, tt.wantStatuses)
+			}
+
+			if _, _, commits := c.calls(); commits != tt.wantCommits {
+				t.Errorf("dispatch() commits = %v, want %v", commits, tt.wantCommits)
+			}
+		})
+	}
+}
+
+func Test_kafkaBroker_consume(t *testing.T) {
+	t.Setenv("KAFKA_POLL_TIMEOUT", "5")
+	statusTopic := "statuses"
+
+	tests := []struct {
+		name              string
+		statusTopic       string
+		wantSubscriptions [][]string
+		wantAssignments   int
+	}{
+		{
+			name:              "Endpoint_topics",
+			wantSubscriptions: [][]string{{"a"}, nil},
+		},
+		{
+			name:            "Status_topic",
+			statusTopic:     statusTopic,
+			wantAssignments: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("KAFKA_STATUS_TOPIC", tt.statusTopic)
+			c := newFakeConsumer()
+			k := newKafkaBroker(log.NewNopLogger(), nil, config.NewConfig(), nil, nil, c)
+			k.topics["a"] = true
+			if tt.statusTopic != "" {
+				k.statusPartitions = []kafka.TopicPartition{{Topic: &statusTopic, Partition: 0, Offset: kafka.OffsetBeginning}}
+				k.handlersChanged = true
+			}
+
+			notified := make(chan string, 1)
+			ctx, cancel := context.WithCancel(context.Background())
+			handler := newHandler("a", notified)
+			handler.Ctx = ctx
+			subscribed := make(chan struct{})
+			go func() {
+				defer close(subscribed)
+				k.SubscribeAsync(*handler)
+			}()
+			go k.consume()
+
+			// the message is only dispatched to a registered handler
+			for registered := false; !registered; time.Sleep(time.Millisecond) {
+				k.handlersMu.Lock()
+				registered = len(k.handlers["a"]) > 0
+				k.handlersMu.Unlock()
+			}
+
+			if tt.statusTopic != "" {
+				c.events <- statusMessage(t, tt.statusTopic, "a", &protobuf.Status{Endpoint: "GET:localhost:8081/hello", Status: "closed"})
+			} else {
+				c.events <- statusMessage(t, "a", "", &protobuf.Status{Endpoint: "GET:localhost:8081/hello", Status: "closed"})
+			}
+
+			select {
+			case <-notified:
+			case <-time.After(time.Second):
+				t.Fatal("consume() did not dispatch the message to the handler")
+			}
+
+			cancel()
+			<-subscribed
+
+			deadline := time.Now().Add(time.Second)
+			for {
+				subscriptions, assignments, _ := c.calls()
+				if reflect.DeepEqual(subscriptions, tt.wantSubscriptions) && len(assignments) == tt.wantAssignments {
+					break
+				}
+				if time.Now().After(deadline) {
+					t.Fatalf("consume() subscriptions = %v, assignments = %v, want %v, %v", subscriptions, assignments, tt.wantSubscriptions, tt.wantAssignments)
+				}
+				time.Sleep(5 * time.Millisecond)
+			}
+
+			close(k.stop)
+			<-k.consumed
+		})
+	}
+}
+
+func Test_kafkaBroker_Close(t *testing.T) {
+	t.Setenv("KAFKA_POLL_TIMEOUT", "5")
+	t.Setenv("KAFKA_FLUSH_TIMEOUT", "100")
+
+	// the clients connect lazily, so no kafka is needed to create and close them
+	producer, err := kafka.NewProducer(&kafka.ConfigMap{"bootstrap.servers": "localhost:1"})
+	if err != nil {
+		t.Fatalf("kafka.NewProducer() error = %v", err)
+	}
+	admin, err := kafka.NewAdminClientFromProducer(producer)
+	if err != nil {
+		t.Fatalf("kafka.NewAdminClientFromProducer() error = %v", err)
+	}
+
+	k := newKafkaBroker(log.NewNopLogger(), nil, config.NewConfig(), producer, admin, newFakeConsumer())
+	go k.handleDeliveryReports()
+	go k.consume()
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		k.Close()
+		k.Close()
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close() did not return when called twice")
+	}
+}
@@ -0,0 +1,147 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/daffarg/distributed-cascading-cb/broker"
+	"github.com/daffarg/distributed-cascading-cb/config"
+	"github.com/daffarg/distributed-cascading-cb/protobuf"
+	"github.com/daffarg/distributed-cascading-cb/util"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"google.golang.org/protobuf/proto"
+)
+
+// Bus holds the topics of the in-memory brokers. Brokers sharing a Bus receive each other's messages,
+// which lets several sidecars run in one process.
+type Bus struct {
+	mu          sync.Mutex
+	retained    map[string]*protobuf.Status
+	subscribers map[string]map[*subscriber]struct{}
+}
+
+// NewBus returns an empty Bus.
+func NewBus() *Bus {
+	return &Bus{
+		retained:    make(map[string]*protobuf.Status),
+		subscribers: make(map[string]map[*subscriber]struct{}),
+	}
+}
+
+// subscriber queues the messages of a topic so that publishing never blocks on a slow consumer.
+type subscriber struct {
+	mu     sync.Mutex
+	queue  []*protobuf.Status
+	signal chan struct{}
+}
+
+func newSubscriber() *subscriber {
+	return &subscriber{
+		signal: make(chan struct{}, 1),
+	}
+}
+
+func (s *subscriber) push(msg *protobuf.Status) {
+	s.mu.Lock()
+	s.queue = append(s.queue, msg)
+	s.mu.Unlock()
+
+	select {
+	case s.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (s *subscriber) pop() []*protobuf.Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queue := s.queue
+	s.queue = nil
+	return queue
+}
+
+type memoryBroker struct {
+	bus      *Bus
+	log      log.Logger
+	cbConfig *config.Config
+}
+
+func NewMemoryBroker(log log.Logger, bus *Bus, cbConfig *config.Config) broker.MessageBroker {
+	return &memoryBroker{
+		bus:      bus,
+		log:      log,
+		cbConfig: cbConfig,
+	}
+}
+
+func (m *memoryBroker) Publish(_ context.Context, topic string, message *protobuf.Status) error {
+	msg := proto.Clone(message).(*protobuf.Status)
+
+	m.bus.mu.Lock()
+	m.bus.retained[topic] = msg
+	for sub := range m.bus.subscribers[topic] {
+		sub.push(msg)
+	}
+	m.bus.mu.Unlock()
+
+	return nil
+}
+
+// Close does nothing, the Bus outlives its brokers
+func (m *memoryBroker) Close() error {
+	return nil
+}
+
+// Subscribe returns the last message published to the topic
+func (m *memoryBroker) Subscribe(_ context.Context, topic string) (*protobuf.Status, error) {
+	m.bus.mu.Lock()
+	defer m.bus.mu.Unlock()
+
+	msg, ok := m.bus.retained[topic]
+	if !ok {
+		return nil, util.ErrUpdatedStatusNotFound
+	}
+
+	return proto.Clone(msg).(*protobuf.Status), nil
+}
+
+func (m *memoryBroker) SubscribeAsync(request broker.SubscribeAsyncRequest) {
+	sub := newSubscriber()
+
+	m.bus.mu.Lock()
+	if m.bus.subscribers[request.Topic] == nil {
+		m.bus.subscribers[request.Topic] = make(map[*subscriber]struct{})
+	}
+	m.bus.subscribers[request.Topic][sub] = struct{}{}
+	m.bus.mu.Unlock()
+
+	defer func() {
+		m.bus.mu.Lock()
+		delete(m.bus.subscribers[request.Topic], sub)
+		m.bus.mu.Unlock()
+	}()
+
+	level.Info(m.log).Log(
+		util.LogMessage, "subscribed to an in-memory topic",
+		util.LogTopic, request.Topic,
+	)
+
+	for {
+		select {
+		case <-request.Ctx.Done():
+			return
+		case <-sub.signal:
+			for _, msg := range sub.pop() {
+				level.Info(m.log).Log(
+					util.LogMessage, "received an in-memory message",
+					util.LogTopic, request.Topic,
+					util.LogStatus, msg,
+				)
+
+				broker.HandleStatus(m.log, m, m.cbConfig, request, proto.Clone(msg).(*protobuf.Status))
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"github.com/daffarg/distributed-cascading-cb/broker"
+	"github.com/daffarg/distributed-cascading-cb/config"
+	"github.com/daffarg/distributed-cascading-cb/protobuf"
+	"github.com/daffarg/distributed-cascading-cb/util"
+	"github.com/go-kit/log"
+	"testing"
+	"time"
+)
+
+func Test_memoryBroker(t *testing.T) {
+	bus := NewBus()
+	publisher := NewMemoryBroker(log.NewNopLogger(), bus, config.NewConfig())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err := publisher.Subscribe(ctx, "topic")
+	if !errors.Is(err, util.ErrUpdatedStatusNotFound) {
+		t.Fatalf("Subscribe() error = %v, want %v", err, util.ErrUpdatedStatusNotFound)
+	}
+
+	// every broker on the bus receives the message, like sidecars with distinct consumer groups
+	received := make(chan string, 2)
+	for i := 0; i < 2; i++ {
+		subscriber := NewMemoryBroker(log.NewNopLogger(), bus, config.NewConfig())
+		go subscriber.SubscribeAsync(broker.SubscribeAsyncRequest{
+			Ctx:   ctx,
+			Topic: "topic",
+			Notify: func(status *protobuf.Status) {
+				received <- status.Endpoint
+			},
+			Get: func(ctx context.Context, key string) (string, error) {
+				return "", util.ErrKeyNotFound
+			},
+			Delete: func(ctx context.Context, keys ...string) error {
+				return nil
+			},
+			GetSetMember: func(ctx context.Context, key string) ([]string, error) {
+				return nil, nil
+			},
+		})
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	msg := &protobuf.Status{Endpoint: "GET:localhost:8081/hello", Status: "closed"}
+	if err = publisher.Publish(ctx, "topic", msg); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case got := <-received:
+			if got != msg.Endpoint {
+				t.Errorf("SubscribeAsync() got = %v, want %v", got, msg.Endpoint)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("SubscribeAsync() received %d messages, want 2", i)
+		}
+	}
+
+	got, err := publisher.Subscribe(ctx, "topic")
+	if err != nil || got.Endpoint != msg.Endpoint {
+		t.Errorf("Subscribe() = %v, %v, want %v", got, err, msg)
+	}
+}
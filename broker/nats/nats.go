@@ -0,0 +1,215 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/daffarg/distributed-cascading-cb/broker"
+	"github.com/daffarg/distributed-cascading-cb/config"
+	"github.com/daffarg/distributed-cascading-cb/protobuf"
+	"github.com/daffarg/distributed-cascading-cb/util"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"google.golang.org/protobuf/proto"
+)
+
+// invalidDurableChars matches the characters that are not allowed in a JetStream consumer name
+var invalidDurableChars = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+type natsBroker struct {
+	conn          *natsgo.Conn
+	js            jetstream.JetStream
+	stream        jetstream.Stream
+	log           log.Logger
+	cbConfig      *config.Config
+	subjectPrefix string
+	group         string
+}
+
+// NewNatsBroker returns a MessageBroker on a JetStream stream, every topic is a subject of the stream
+// and every consumer group a durable consumer per subject
+func NewNatsBroker(log log.Logger, url string, cbConfig *config.Config) (broker.MessageBroker, error) {
+	nc, err := natsgo.Connect(url)
+	if err != nil {
+		return nil, err
+	}
+
+	js, err := jetstream.New(nc)
+	if err != nil {
+		nc.Close()
+		return nil, err
+	}
+
+	streamName := util.GetEnv("NATS_STREAM", "CB_STATUS")
+	subjectPrefix := util.GetEnv("NATS_SUBJECT_PREFIX", "cb.status")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stream, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:              streamName,
+		Subjects:          []string{fmt.Sprintf("%s.>", subjectPrefix)},
+		MaxMsgsPerSubject: int64(util.GetIntEnv("NATS_MAX_MSGS_PER_SUBJECT", 10)),
+		Replicas:          util.GetIntEnv("NATS_REPLICAS", 1),
+	})
+	if err != nil {
+		nc.Close()
+		return nil, err
+	}
+
+	return &natsBroker{
+		conn:          nc,
+		js:            js,
+		stream:        stream,
+		log:           log,
+		cbConfig:      cbConfig,
+		subjectPrefix: subjectPrefix,
+		group:         os.Getenv("CB_CONSUMER_GROUP"),
+	}, nil
+}
+
+func (n *natsBroker) subject(topic string) string {
+	return fmt.Sprintf("%s.%s", n.subjectPrefix, topic)
+}
+
+func (n *natsBroker) Publish(ctx context.Context, topic string, message *protobuf.Status) error {
+	msgBuf, err := proto.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	_, err = n.js.Publish(ctx, n.subject(topic), msgBuf)
+	return err
+}
+
+// Close drains the subscriptions and pending publishes before closing the connection
+func (n *natsBroker) Close() error {
+	return n.conn.Drain()
+}
+
+// Subscribe returns the last message of the topic subject
+func (n *natsBroker) Subscribe(ctx context.Context, topic string) (*protobuf.Status, error) {
+	rawMsg, err := n.stream.GetLastMsgForSubject(ctx, n.subject(topic))
+	if err != nil {
+		if errors.Is(err, jetstream.ErrMsgNotFound) {
+			return nil, util.ErrUpdatedStatusNotFound
+		}
+		return nil, err
+	}
+
+	msg := &protobuf.Status{}
+	err = proto.Unmarshal(rawMsg.Data, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	level.Info(n.log).Log(
+		util.LogMessage, "received a new cb status from nats",
+		util.LogTopic, topic,
+		util.LogStatus, msg,
+	)
+
+	return msg, nil
+}
+
+func (n *natsBroker) SubscribeAsync(request broker.SubscribeAsyncRequest) {
+	var consumer jetstream.Consumer
+	for {
+		var err error
+		consumer, err = n.stream.CreateOrUpdateConsumer(request.Ctx, jetstream.ConsumerConfig{
+			Durable:       invalidDurableChars.ReplaceAllString(fmt.Sprintf("%s_%s", n.group, request.Topic), "_"),
+			FilterSubject: n.subject(request.Topic),
+			DeliverPolicy: jetstream.DeliverNewPolicy,
+			AckPolicy:     jetstream.AckExplicitPolicy,
+		})
+		if err == nil {
+			break
+		}
+
+		level.Warn(n.log).Log(
+			util.LogMessage, "failed to create nats consumer",
+			util.LogTopic, request.Topic,
+			util.LogError, err,
+		)
+		if request.Ctx.Err() != nil {
+			return
+		}
+		time.Sleep(time.Duration(util.GetIntEnv("RETRY_SUBSCRIBE_INTERVAL", 10)) * time.Second)
+	}
+
+	messages, err := consumer.Messages()
+	if err != nil {
+		level.Error(n.log).Log(
+			util.LogMessage, "failed to consume nats messages",
+			util.LogTopic, request.Topic,
+			util.LogError, err,
+		)
+		return
+	}
+	defer messages.Stop()
+
+	go func() {
+		<-request.Ctx.Done()
+		messages.Stop()
+	}()
+
+	level.Info(n.log).Log(
+		util.LogMessage, "subscribed to a nats subject",
+		util.LogTopic, request.Topic,
+	)
+
+	for {
+		natsMsg, err := messages.Next()
+		if err != nil {
+			if errors.Is(err, jetstream.ErrMsgIteratorClosed) || errors.Is(err, natsgo.ErrConnectionClosed) || errors.Is(err, natsgo.ErrConnectionDraining) {
+				return
+			}
+
+			level.Error(n.log).Log(
+				util.LogMessage, "failed to read a message from nats",
+				util.LogTopic, request.Topic,
+				util.LogError, err,
+			)
+
+			select {
+			case <-request.Ctx.Done():
+				return
+			case <-time.After(time.Duration(util.GetIntEnv("NATS_RETRY_READ_INTERVAL", 1000)) * time.Millisecond):
+			}
+			continue
+		}
+
+		msg := &protobuf.Status{}
+		err = proto.Unmarshal(natsMsg.Data(), msg)
+		if err != nil {
+			level.Error(n.log).Log(
+				util.LogMessage, "failed to unmarshal nats message",
+				util.LogError, err,
+				util.LogTopic, request.Topic,
+			)
+		} else {
+			level.Info(n.log).Log(
+				util.LogMessage, "received a nats message",
+				util.LogTopic, request.Topic,
+				util.LogStatus, msg,
+			)
+
+			broker.HandleStatus(n.log, n, n.cbConfig, request, msg)
+		}
+
+		err = natsMsg.Ack()
+		if err != nil {
+			level.Error(n.log).Log(
+				util.LogMessage, "failed to acknowledge a nats message",
+				util.LogError, err,
+				util.LogTopic, request.Topic,
+			)
+		}
+	}
+}
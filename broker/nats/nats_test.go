@@ -0,0 +1,111 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/daffarg/distributed-cascading-cb/broker"
+	"github.com/daffarg/distributed-cascading-cb/config"
+	"github.com/daffarg/distributed-cascading-cb/protobuf"
+	"github.com/daffarg/distributed-cascading-cb/util"
+	"github.com/go-kit/log"
+	"github.com/nats-io/nats-server/v2/test"
+)
+
+func Test_natsBroker(t *testing.T) {
+	opts := test.DefaultTestOptions
+	opts.Port = -1
+	opts.JetStream = true
+	opts.StoreDir = t.TempDir()
+	server := test.RunServer(&opts)
+	defer server.Shutdown()
+
+	t.Setenv("CB_CONSUMER_GROUP", "SERVICE_X")
+
+	n, err := NewNatsBroker(log.NewNopLogger(), server.ClientURL(), config.NewConfig())
+	if err != nil {
+		t.Fatalf("NewNatsBroker() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err = n.Subscribe(ctx, "topic")
+	if !errors.Is(err, util.ErrUpdatedStatusNotFound) {
+		t.Fatalf("Subscribe() error = %v, want %v", err, util.ErrUpdatedStatusNotFound)
+	}
+
+	received := make(chan string, 1)
+	subscribed := make(chan struct{})
+	go func() {
+		defer close(subscribed)
+		n.SubscribeAsync(broker.SubscribeAsyncRequest{
+			Ctx:   ctx,
+			Topic: "topic",
+			Notify: func(status *protobuf.Status) {
+				received <- status.Endpoint
+			},
+			Get: func(ctx context.Context, key string) (string, error) {
+				return "", util.ErrKeyNotFound
+			},
+			Delete: func(ctx context.Context, keys ...string) error {
+				return nil
+			},
+			GetSetMember: func(ctx context.Context, key string) ([]string, error) {
+				return nil, nil
+			},
+		})
+	}()
+	// the durable consumer only delivers the messages published after it is created
+	time.Sleep(100 * time.Millisecond)
+
+	msg := &protobuf.Status{Endpoint: "GET:localhost:8081/hello", Status: "closed"}
+	if err = n.Publish(ctx, "topic", msg); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	select {
+	case got := <-received:
+		if got != msg.Endpoint {
+			t.Errorf("SubscribeAsync() got = %v, want %v", got, msg.Endpoint)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("SubscribeAsync() did not receive the message")
+	}
+
+	got, err := n.Subscribe(ctx, "topic")
+	if err != nil || got.Endpoint != msg.Endpoint {
+		t.Errorf("Subscribe() = %v, %v, want %v", got, err, msg)
+	}
+
+	// the subscription ends with the connection instead of retrying to read from it
+	if err = n.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	select {
+	case <-subscribed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("SubscribeAsync() did not return after Close()")
+	}
+}
+
+func Test_NewNatsBroker_closesConnectionOnError(t *testing.T) {
+	// the stream cannot be created on a server without JetStream
+	opts := test.DefaultTestOptions
+	opts.Port = -1
+	server := test.RunServer(&opts)
+	defer server.Shutdown()
+
+	if _, err := NewNatsBroker(log.NewNopLogger(), server.ClientURL(), config.NewConfig()); err == nil {
+		t.Fatal("NewNatsBroker() error = nil, want an error")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for server.NumClients() > 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("NewNatsBroker() left %v connections open", server.NumClients())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
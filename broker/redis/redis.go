@@ -0,0 +1,220 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/daffarg/distributed-cascading-cb/broker"
+	"github.com/daffarg/distributed-cascading-cb/config"
+	"github.com/daffarg/distributed-cascading-cb/protobuf"
+	"github.com/daffarg/distributed-cascading-cb/util"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/redis/go-redis/extra/redisotel/v9"
+	goredis "github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/proto"
+)
+
+// statusField is the stream entry field holding the marshalled status
+const statusField = "status"
+
+type redisBroker struct {
+	client    goredis.UniversalClient
+	log       log.Logger
+	cbConfig  *config.Config
+	group     string
+	consumer  string
+	maxLen    int64
+	readCount int64
+	block     time.Duration
+}
+
+// NewRedisBroker returns a MessageBroker on Redis Streams, every topic is a stream
+// read by the consumer group named by CB_CONSUMER_GROUP
+func NewRedisBroker(log log.Logger, host, port, password string, db int, cbConfig *config.Config) (broker.MessageBroker, error) {
+	client := goredis.NewClient(&goredis.Options{
+		Addr:     fmt.Sprintf("%s:%s", host, port),
+		Password: password,
+		DB:       db,
+	})
+
+	if err := redisotel.InstrumentTracing(client); err != nil {
+		return nil, err
+	}
+
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		return nil, err
+	}
+
+	return newRedisBroker(log, client, cbConfig), nil
+}
+
+func newRedisBroker(log log.Logger, client goredis.UniversalClient, cbConfig *config.Config) *redisBroker {
+	consumer, err := os.Hostname()
+	if err != nil {
+		consumer = "cb"
+	}
+
+	return &redisBroker{
+		client:    client,
+		log:       log,
+		cbConfig:  cbConfig,
+		group:     os.Getenv("CB_CONSUMER_GROUP"),
+		consumer:  util.GetEnv("CB_CONSUMER_NAME", consumer),
+		maxLen:    int64(util.GetIntEnv("REDIS_STREAM_MAX_LEN", 1000)),
+		readCount: int64(util.GetIntEnv("REDIS_STREAM_READ_COUNT", 10)),
+		block:     time.Duration(util.GetIntEnv("REDIS_STREAM_BLOCK", 5000)) * time.Millisecond,
+	}
+}
+
+func (r *redisBroker) Publish(ctx context.Context, topic string, message *protobuf.Status) error {
+	msgBuf, err := proto.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	return r.client.XAdd(ctx, &goredis.XAddArgs{
+		Stream: topic,
+		MaxLen: r.maxLen,
+		Approx: true,
+		Values: map[string]interface{}{statusField: msgBuf},
+	}).Err()
+}
+
+func (r *redisBroker) Close() error {
+	return r.client.Close()
+}
+
+// Subscribe returns the latest status of the topic
+func (r *redisBroker) Subscribe(ctx context.Context, topic string) (*protobuf.Status, error) {
+	entries, err := r.client.XRevRangeN(ctx, topic, "+", "-", 1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		return nil, util.ErrUpdatedStatusNotFound
+	}
+
+	msg, err := decodeStatus(entries[0])
+	if err != nil {
+		return nil, err
+	}
+
+	level.Info(r.log).Log(
+		util.LogMessage, "received a new cb status from redis",
+		util.LogTopic, topic,
+		util.LogStatus, msg,
+	)
+
+	return msg, nil
+}
+
+func (r *redisBroker) SubscribeAsync(request broker.SubscribeAsyncRequest) {
+	for {
+		// the group only receives the statuses published after it is created, like a kafka consumer reading from the latest offset
+		err := r.client.XGroupCreateMkStream(request.Ctx, request.Topic, r.group, "$").Err()
+		if err == nil || strings.HasPrefix(err.Error(), "BUSYGROUP") {
+			break
+		}
+
+		level.Warn(r.log).Log(
+			util.LogMessage, "failed to create redis consumer group",
+			util.LogTopic, request.Topic,
+			util.LogError, err,
+		)
+		select {
+		case <-request.Ctx.Done():
+			return
+		case <-time.After(time.Duration(util.GetIntEnv("RETRY_SUBSCRIBE_INTERVAL", 10)) * time.Second):
+		}
+	}
+
+	level.Info(r.log).Log(
+		util.LogMessage, "subscribed to a redis stream",
+		util.LogTopic, request.Topic,
+	)
+
+	// read the entries left pending by a previous run of this consumer first, then the new ones
+	lastID := "0"
+	for {
+		if request.Ctx.Err() != nil {
+			return
+		}
+
+		streams, err := r.client.XReadGroup(request.Ctx, &goredis.XReadGroupArgs{
+			Group:    r.group,
+			Consumer: r.consumer,
+			Streams:  []string{request.Topic, lastID},
+			Count:    r.readCount,
+			Block:    r.block,
+		}).Result()
+		if err != nil {
+			if !errors.Is(err, goredis.Nil) && request.Ctx.Err() == nil {
+				level.Error(r.log).Log(
+					util.LogMessage, "failed to read a message from redis",
+					util.LogTopic, request.Topic,
+					util.LogError, err,
+				)
+				select {
+				case <-request.Ctx.Done():
+				case <-time.After(time.Duration(util.GetIntEnv("RETRY_SUBSCRIBE_INTERVAL", 10)) * time.Second):
+				}
+			}
+			continue
+		}
+
+		for _, stream := range streams {
+			if lastID == "0" && len(stream.Messages) == 0 {
+				lastID = ">"
+			}
+
+			for _, entry := range stream.Messages {
+				msg, err := decodeStatus(entry)
+				if err != nil {
+					level.Error(r.log).Log(
+						util.LogMessage, "failed to unmarshal redis message",
+						util.LogError, err,
+						util.LogTopic, request.Topic,
+					)
+				} else {
+					level.Info(r.log).Log(
+						util.LogMessage, "received a redis message",
+						util.LogTopic, request.Topic,
+						util.LogStatus, msg,
+					)
+
+					broker.HandleStatus(r.log, r, r.cbConfig, request, msg)
+				}
+
+				err = r.client.XAck(context.Background(), request.Topic, r.group, entry.ID).Err()
+				if err != nil {
+					level.Error(r.log).Log(
+						util.LogMessage, "failed to acknowledge a redis message",
+						util.LogError, err,
+						util.LogTopic, request.Topic,
+					)
+				}
+			}
+		}
+	}
+}
+
+func decodeStatus(entry goredis.XMessage) (*protobuf.Status, error) {
+	value, ok := entry.Values[statusField].(string)
+	if !ok {
+		return nil, fmt.Errorf("stream entry %s has no %s field", entry.ID, statusField)
+	}
+
+	msg := &protobuf.Status{}
+	err := proto.Unmarshal([]byte(value), msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
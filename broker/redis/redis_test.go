@@ -0,0 +1,90 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/daffarg/distributed-cascading-cb/broker"
+	"github.com/daffarg/distributed-cascading-cb/config"
+	"github.com/daffarg/distributed-cascading-cb/protobuf"
+	"github.com/daffarg/distributed-cascading-cb/util"
+	"github.com/go-kit/log"
+	goredis "github.com/redis/go-redis/v9"
+	"testing"
+	"time"
+)
+
+func Test_redisBroker(t *testing.T) {
+	server := miniredis.RunT(t)
+	t.Setenv("CB_CONSUMER_GROUP", "SERVICE_X")
+	t.Setenv("REDIS_STREAM_BLOCK", "50")
+
+	r := newRedisBroker(log.NewNopLogger(), goredis.NewClient(&goredis.Options{Addr: server.Addr()}), config.NewConfig())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err := r.Subscribe(ctx, "topic")
+	if !errors.Is(err, util.ErrUpdatedStatusNotFound) {
+		t.Fatalf("Subscribe() error = %v, want %v", err, util.ErrUpdatedStatusNotFound)
+	}
+
+	received := make(chan *protobuf.Status, 1)
+	go r.SubscribeAsync(broker.SubscribeAsyncRequest{
+		Ctx:   ctx,
+		Topic: "topic",
+		Notify: func(status *protobuf.Status) {
+			received <- status
+		},
+		Get: func(ctx context.Context, key string) (string, error) {
+			return "", util.ErrKeyNotFound
+		},
+		Delete: func(ctx context.Context, keys ...string) error {
+			return nil
+		},
+		GetSetMember: func(ctx context.Context, key string) ([]string, error) {
+			return nil, nil
+		},
+	})
+	time.Sleep(100 * time.Millisecond)
+
+	msg := &protobuf.Status{Endpoint: "GET:localhost:8081/hello", Status: "closed"}
+	if err = r.Publish(ctx, "topic", msg); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	select {
+	case got := <-received:
+		if got.Endpoint != msg.Endpoint || got.Status != msg.Status {
+			t.Errorf("SubscribeAsync() got = %v, want %v", got, msg)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("SubscribeAsync() received no message")
+	}
+
+	got, err := r.Subscribe(ctx, "topic")
+	if err != nil || got.Endpoint != msg.Endpoint {
+		t.Errorf("Subscribe() = %v, %v, want %v", got, err, msg)
+	}
+}
+
+func Test_redisBroker_SubscribeAsync_canceled(t *testing.T) {
+	server := miniredis.RunT(t)
+	r := newRedisBroker(log.NewNopLogger(), goredis.NewClient(&goredis.Options{Addr: server.Addr()}), config.NewConfig())
+	// the consumer group cannot be created while the server is down
+	server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	returned := make(chan struct{})
+	go func() {
+		defer close(returned)
+		r.SubscribeAsync(broker.SubscribeAsyncRequest{Ctx: ctx, Topic: "topic"})
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-returned:
+	case <-time.After(time.Second):
+		t.Fatal("SubscribeAsync() did not return once its context was canceled")
+	}
+}
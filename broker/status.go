@@ -0,0 +1,241 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"github.com/daffarg/distributed-cascading-cb/circuitbreaker"
+	"github.com/daffarg/distributed-cascading-cb/config"
+	"github.com/daffarg/distributed-cascading-cb/protobuf"
+	"github.com/daffarg/distributed-cascading-cb/util"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"time"
+)
+
+// HandleStatus stores a status received from a subscribed topic and cascades it to the endpoints requiring
+// its endpoint, unless the endpoint still has an available alternative. A half-open or closed status clears
+// the stored statuses instead. It is shared by the broker implementations.
+func HandleStatus(log log.Logger, b MessageBroker, cbConfig *config.Config, request SubscribeAsyncRequest, msg *protobuf.Status) {
+	if !isFirstDelivery(b, msg) {
+		level.Info(log).Log(
+			util.LogMessage, "skipping a circuit breaker status already handled",
+			util.LogStatus, msg,
+		)
+		return
+	}
+
+	if request.Notify != nil {
+		request.Notify(msg)
+	}
+
+	if msg.Status == circuitbreaker.StateClosed.String() || msg.Status == circuitbreaker.StateHalfOpen.String() {
+		handleRecovery(log, b, request, msg)
+		return
+	}
+
+	timestamp, _ := time.Parse(time.RFC3339, msg.Timestamp)
+	expiredTime := timestamp.Add(time.Duration(msg.Timeout) * time.Second)
+	if time.Now().Before(expiredTime) {
+		timeout := time.Until(expiredTime)
+		isThereAlt := false
+		if alt, ok := cbConfig.AlternativeEndpoints[msg.Endpoint]; ok {
+			for _, ep := range alt.Alternatives {
+				endpointName := util.FormEndpointName(ep.Endpoint, ep.Method)
+				_, err := request.Get(context.Background(), util.FormEndpointStatusKey(endpointName))
+				if err != nil {
+					if errors.Is(err, util.ErrKeyNotFound) {
+						isThereAlt = true
+						break
+					} else {
+						level.Error(log).Log(
+							util.LogMessage, "failed to get endpoint status from db",
+							util.LogEndpoint, endpointName,
+							util.LogError, err,
+						)
+					}
+				}
+			}
+		}
+
+		// the statuses are handled in the order they are received, so a recovery cannot be overtaken by an older trip
+		if !isThereAlt {
+			requiringEndpoints, err := request.GetSetMember(context.Background(), util.FormRequiringEndpointsKey(msg.Endpoint))
+			if err != nil {
+				level.Error(log).Log(
+					util.LogMessage, "failed to get requiring endpoints from db",
+					util.LogError, err,
+					util.LogCircuitBreakerEndpoint, msg.Endpoint,
+					util.LogCircuitBreakerNewStatus, msg.Status,
+				)
+
+				storeStatus(log, request, msg.Endpoint, msg, timeout)
+			} else {
+				for _, ep := range requiringEndpoints {
+					// the endpoint is still stored when the status is not forwarded to it
+					if canCascade(log, msg, ep) {
+						encodedTopic := util.EncodeTopic(ep)
+						message := CascadeStatus(msg, ep, timeout)
+
+						err = b.Publish(context.Background(), encodedTopic, message)
+						if err != nil {
+							level.Error(log).Log(
+								util.LogMessage, "failed to publish circuit breaker status",
+								util.LogError, err,
+								util.LogCircuitBreakerEndpoint, ep,
+								util.LogCircuitBreakerNewStatus, msg.Status,
+							)
+						} else {
+							level.Info(log).Log(
+								util.LogMessage, "published circuit breaker status",
+								util.LogStatus, message,
+							)
+						}
+					}
+
+					storeStatus(log, request, ep, msg, timeout)
+				}
+			}
+		} else {
+			// the status is not published again since it was received from the topic of its endpoint
+			level.Info(log).Log(
+				util.LogMessage, "there are still alternative endpoints, only storing the endpoint not its requirings",
+				util.LogCircuitBreakerEndpoint, msg.Endpoint,
+				util.LogCircuitBreakerNewStatus, msg.Status,
+			)
+
+			storeStatus(log, request, msg.Endpoint, msg, timeout)
+		}
+	}
+}
+
+// storeStatus stores the status of the endpoint along with its root cause, so only the recovery of that root cause
+// clears it
+func storeStatus(log log.Logger, request SubscribeAsyncRequest, endpoint string, msg *protobuf.Status, timeout time.Duration) {
+	err := request.Set(context.Background(), util.FormEndpointStatusKey(endpoint), msg.Status, timeout)
+	if err == nil {
+		err = request.Set(context.Background(), util.FormRootCauseKey(endpoint), RootCauseEndpoint(msg), timeout)
+	}
+	if err != nil {
+		level.Error(log).Log(
+			util.LogMessage, "failed to set circuit breaker status to db",
+			util.LogError, err,
+			util.LogCircuitBreakerEndpoint, endpoint,
+			util.LogCircuitBreakerNewStatus, msg.Status,
+		)
+	}
+}
+
+// IsCausedBy reports whether the stored status of the endpoint was caused by the root cause endpoint. A status stored
+// without its root cause is taken as caused by it, like every status was before the root causes were stored.
+func IsCausedBy(ctx context.Context, get func(ctx context.Context, key string) (string, error), endpoint, rootCause string) (bool, error) {
+	storedRootCause, err := get(ctx, util.FormRootCauseKey(endpoint))
+	if errors.Is(err, util.ErrKeyNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return true, err
+	}
+	return storedRootCause == rootCause, nil
+}
+
+// handleRecovery clears the statuses of a half-open or closed endpoint and of the endpoints requiring it, and
+// forwards the recovery to the requiring endpoints so their callers resume without waiting for the statuses to expire.
+// Only the statuses caused by the recovered root cause are cleared and forwarded, the others still hold.
+func handleRecovery(log log.Logger, b MessageBroker, request SubscribeAsyncRequest, msg *protobuf.Status) {
+	rootCause := RootCauseEndpoint(msg)
+	if !clearStatus(log, request, msg.Endpoint, rootCause) {
+		return
+	}
+
+	requiringEndpoints, err := request.GetSetMember(context.Background(), util.FormRequiringEndpointsKey(msg.Endpoint))
+	if err != nil {
+		level.Error(log).Log(
+			util.LogMessage, "failed to get requiring endpoints from db",
+			util.LogError, err,
+			util.LogCircuitBreakerEndpoint, msg.Endpoint,
+			util.LogCircuitBreakerNewStatus, msg.Status,
+		)
+		return
+	}
+
+	for _, ep := range requiringEndpoints {
+		if !canCascade(log, msg, ep) || !clearStatus(log, request, ep, rootCause) {
+			continue
+		}
+
+		message := CascadeStatus(msg, ep, 0)
+		err = b.Publish(context.Background(), util.EncodeTopic(ep), message)
+		if err != nil {
+			level.Error(log).Log(
+				util.LogMessage, "failed to publish circuit breaker status",
+				util.LogError, err,
+				util.LogCircuitBreakerEndpoint, ep,
+				util.LogCircuitBreakerNewStatus, msg.Status,
+			)
+		} else {
+			level.Info(log).Log(
+				util.LogMessage, "published circuit breaker status",
+				util.LogStatus, message,
+			)
+		}
+	}
+}
+
+// clearStatus deletes the status of the endpoint when it was caused by the root cause, and reports whether it was
+func clearStatus(log log.Logger, request SubscribeAsyncRequest, endpoint, rootCause string) bool {
+	isCaused, err := IsCausedBy(context.Background(), request.Get, endpoint, rootCause)
+	if err != nil {
+		level.Error(log).Log(
+			util.LogMessage, "failed to get circuit breaker root cause from db",
+			util.LogError, err,
+			util.LogCircuitBreakerEndpoint, endpoint,
+		)
+	}
+	if !isCaused {
+		level.Info(log).Log(
+			util.LogMessage, "circuit breaker status has another root cause, keeping it",
+			util.LogCircuitBreakerEndpoint, endpoint,
+			util.LogRootCauseEndpoint, rootCause,
+		)
+		return false
+	}
+
+	err = request.Delete(context.Background(), util.FormEndpointStatusKey(endpoint), util.FormRootCauseKey(endpoint))
+	if err != nil {
+		level.Error(log).Log(
+			util.LogMessage, "failed to delete circuit breaker status from db",
+			util.LogError, err,
+			util.LogCircuitBreakerEndpoint, endpoint,
+		)
+	}
+	return true
+}
+
+// canCascade reports whether the status may be forwarded to the requiring endpoint. A cascade stops after
+// CB_MAX_CASCADE_HOPS hops or when it comes back to an endpoint it went through, so cyclic dependencies do not loop.
+func canCascade(log log.Logger, msg *protobuf.Status, endpoint string) bool {
+	// the requirings of an endpoint include the endpoint itself, which received the status already
+	if endpoint == msg.Endpoint {
+		return false
+	}
+
+	if msg.HopCount >= uint32(util.GetIntEnv("CB_MAX_CASCADE_HOPS", 10)) {
+		level.Warn(log).Log(
+			util.LogMessage, "cascade reached the hop limit, not forwarding the status",
+			util.LogEndpoint, endpoint,
+			util.LogStatus, msg,
+		)
+		return false
+	}
+
+	if IsVisited(msg, endpoint) {
+		level.Warn(log).Log(
+			util.LogMessage, "cascade already went through the endpoint, not forwarding the status",
+			util.LogEndpoint, endpoint,
+			util.LogStatus, msg,
+		)
+		return false
+	}
+
+	return true
+}
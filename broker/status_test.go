@@ -0,0 +1,252 @@
+package broker_test
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/daffarg/distributed-cascading-cb/broker"
+	"github.com/daffarg/distributed-cascading-cb/config"
+	"github.com/daffarg/distributed-cascading-cb/protobuf"
+	"github.com/daffarg/distributed-cascading-cb/util"
+	"github.com/go-kit/log"
+)
+
+type recordingBroker struct {
+	broker.MessageBroker
+	mu        sync.Mutex
+	published []string
+	messages  []*protobuf.Status
+}
+
+func (r *recordingBroker) Publish(_ context.Context, _ string, message *protobuf.Status) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.published = append(r.published, message.Endpoint)
+	r.messages = append(r.messages, message)
+	return nil
+}
+
+func TestHandleStatus_cascadeProtection(t *testing.T) {
+	now := time.Now().Format(time.RFC3339)
+
+	tests := []struct {
+		name       string
+		msg        *protobuf.Status
+		requirings []string
+		deliveries int
+		want       []string
+	}{
+		{
+			name:       "Endpoint_is_its_own_requiring",
+			msg:        broker.NewStatus("GET:b/api", "open", time.Minute),
+			requirings: []string{"GET:b/api", "GET:a/api"},
+			deliveries: 1,
+			want:       []string{"GET:a/api"},
+		},
+		{
+			name: "Cycle_back_to_root_cause",
+			msg: broker.CascadeStatus(
+				broker.NewStatus("GET:a/api", "open", time.Minute), "GET:b/api", time.Minute,
+			),
+			requirings: []string{"GET:a/api", "GET:c/api"},
+			deliveries: 1,
+			want:       []string{"GET:c/api"},
+		},
+		{
+			name:       "Hop_limit_reached",
+			msg:        &protobuf.Status{Endpoint: "GET:b/api", Status: "open", Timeout: 60, Timestamp: now, MessageId: "hop-limit", HopCount: 10},
+			requirings: []string{"GET:a/api"},
+			deliveries: 1,
+		},
+		{
+			name:       "Delivered_twice",
+			msg:        broker.NewStatus("GET:b/api", "open", time.Minute),
+			requirings: []string{"GET:a/api"},
+			deliveries: 2,
+			want:       []string{"GET:a/api"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &recordingBroker{}
+			stored := make(chan string, len(tt.requirings)*tt.deliveries)
+			request := broker.SubscribeAsyncRequest{
+				Ctx: context.Background(),
+				Set: func(ctx context.Context, key, value string, exp time.Duration) error {
+					// the root cause is stored along with every status
+					if strings.HasPrefix(key, util.StatusKeyPrefix) {
+						stored <- key
+					}
+					return nil
+				},
+				Get: func(ctx context.Context, key string) (string, error) {
+					return "", util.ErrKeyNotFound
+				},
+				GetSetMember: func(ctx context.Context, key string) ([]string, error) {
+					return tt.requirings, nil
+				},
+			}
+
+			for i := 0; i < tt.deliveries; i++ {
+				broker.HandleStatus(log.NewNopLogger(), b, config.NewConfig(), request, tt.msg)
+			}
+
+			// every requiring endpoint is stored once the status is handled, even when it is not forwarded
+			for range tt.requirings {
+				select {
+				case <-stored:
+				case <-time.After(time.Second):
+					t.Fatal("HandleStatus() did not store the requiring endpoints")
+				}
+			}
+			select {
+			case key := <-stored:
+				t.Errorf("HandleStatus() stored %v again", key)
+			case <-time.After(10 * time.Millisecond):
+			}
+
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			sort.Strings(b.published)
+			if !slices.Equal(b.published, tt.want) {
+				t.Errorf("published = %v, want %v", b.published, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleStatus_diamond(t *testing.T) {
+	// d requires b and c, which both require a, so the trip of a reaches d through both of them
+	root := broker.NewStatus("GET:a/api", "open", time.Minute)
+	viaB := broker.CascadeStatus(broker.CascadeStatus(root, "GET:b/api", time.Minute), "GET:d/api", time.Minute)
+	viaC := broker.CascadeStatus(broker.CascadeStatus(root, "GET:c/api", time.Minute), "GET:d/api", time.Minute)
+
+	b := &recordingBroker{}
+	request := broker.SubscribeAsyncRequest{
+		Ctx: context.Background(),
+		Set: func(ctx context.Context, key, value string, exp time.Duration) error {
+			return nil
+		},
+		Get: func(ctx context.Context, key string) (string, error) {
+			return "", util.ErrKeyNotFound
+		},
+		GetSetMember: func(ctx context.Context, key string) ([]string, error) {
+			return []string{"GET:d/api", "GET:e/api"}, nil
+		},
+	}
+
+	broker.HandleStatus(log.NewNopLogger(), b, config.NewConfig(), request, viaB)
+	broker.HandleStatus(log.NewNopLogger(), b, config.NewConfig(), request, viaC)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if want := []string{"GET:e/api"}; !slices.Equal(b.published, want) {
+		t.Errorf("published = %v, want %v", b.published, want)
+	}
+}
+
+func TestHandleStatus_cascadedTimeout(t *testing.T) {
+	b := &recordingBroker{}
+	stored := make(chan time.Duration, 1)
+	request := broker.SubscribeAsyncRequest{
+		Ctx: context.Background(),
+		Set: func(ctx context.Context, key, value string, exp time.Duration) error {
+			if strings.HasPrefix(key, util.StatusKeyPrefix) {
+				stored <- exp
+			}
+			return nil
+		},
+		Get: func(ctx context.Context, key string) (string, error) {
+			return "", util.ErrKeyNotFound
+		},
+		GetSetMember: func(ctx context.Context, key string) ([]string, error) {
+			return []string{"GET:a/api"}, nil
+		},
+	}
+
+	// a forced open status lasts longer than the default timeout of the local breakers
+	broker.HandleStatus(log.NewNopLogger(), b, config.NewConfig(), request, broker.NewStatus("GET:b/api", "forced-open", 24*time.Hour))
+
+	select {
+	case exp := <-stored:
+		if exp <= 24*time.Hour-5*time.Second || exp > 24*time.Hour {
+			t.Errorf("stored expiration = %v, want the remaining 24h", exp)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("HandleStatus() did not store the requiring endpoint")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.messages) != 1 {
+		t.Fatalf("published %v statuses, want 1", len(b.messages))
+	}
+	if got := time.Duration(b.messages[0].Timeout) * time.Second; got <= 24*time.Hour-5*time.Second || got > 24*time.Hour {
+		t.Errorf("cascaded timeout = %v, want the remaining 24h", got)
+	}
+}
+
+func TestHandleStatus_recovery(t *testing.T) {
+	tests := []struct {
+		name          string
+		rootCauses    map[string]string
+		wantDeleted   []string
+		wantPublished []string
+	}{
+		{
+			name:          "Caused_by_recovered_endpoint",
+			rootCauses:    map[string]string{"GET:b/api": "GET:b/api", "GET:a/api": "GET:b/api"},
+			wantDeleted:   []string{"GET:b/api", "GET:a/api"},
+			wantPublished: []string{"GET:a/api"},
+		},
+		{
+			name:        "Caused_by_another_endpoint",
+			rootCauses:  map[string]string{"GET:b/api": "GET:b/api", "GET:a/api": "GET:c/api"},
+			wantDeleted: []string{"GET:b/api"},
+		},
+		{
+			name:          "Root_cause_not_stored",
+			wantDeleted:   []string{"GET:b/api", "GET:a/api"},
+			wantPublished: []string{"GET:a/api"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &recordingBroker{}
+			var deleted []string
+			request := broker.SubscribeAsyncRequest{
+				Ctx: context.Background(),
+				Get: func(ctx context.Context, key string) (string, error) {
+					rootCause, ok := tt.rootCauses[strings.TrimPrefix(key, util.RootCauseKeyPrefix)]
+					if !ok {
+						return "", util.ErrKeyNotFound
+					}
+					return rootCause, nil
+				},
+				Delete: func(ctx context.Context, keys ...string) error {
+					// the status and its root cause are deleted together
+					deleted = append(deleted, util.GetEndpointFromStatusKey(keys[0]))
+					return nil
+				},
+				GetSetMember: func(ctx context.Context, key string) ([]string, error) {
+					return []string{"GET:b/api", "GET:a/api"}, nil
+				},
+			}
+
+			broker.HandleStatus(log.NewNopLogger(), b, config.NewConfig(), request, broker.NewStatus("GET:b/api", "half-open", 0))
+
+			if !slices.Equal(deleted, tt.wantDeleted) {
+				t.Errorf("deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+			if !slices.Equal(b.published, tt.wantPublished) {
+				t.Errorf("published = %v, want %v", b.published, tt.wantPublished)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package circuitbreaker
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// BackoffPolicy configures how the period of the open state grows on consecutive reopens:
+//
+// Base is the period of the first open state.
+// If Base is less than or equal to 0, the Timeout of the CircuitBreaker is used.
+//
+// Multiplier is the factor applied to the period on each reopen.
+// If Multiplier is less than or equal to 1, the multiplier is set to 2.
+//
+// Cap is the maximum period of the open state before jitter is applied.
+// If Cap is less than or equal to 0, the period is not capped.
+//
+// Jitter is the fraction of the period that is randomly added or subtracted,
+// so that many CircuitBreakers opened at the same time do not probe in lockstep.
+// Jitter is clamped between 0 and 1.
+type BackoffPolicy struct {
+	Base       time.Duration
+	Multiplier float64
+	Cap        time.Duration
+	Jitter     float64
+}
+
+const defaultBackoffMultiplier = 2
+
+func (b *BackoffPolicy) withDefaults(timeout time.Duration) *BackoffPolicy {
+	policy := *b
+
+	if policy.Base <= 0 {
+		policy.Base = timeout
+	}
+
+	if policy.Multiplier <= 1 {
+		policy.Multiplier = defaultBackoffMultiplier
+	}
+
+	policy.Jitter = math.Max(0, math.Min(1, policy.Jitter))
+
+	return &policy
+}
+
+// timeout returns the period of the open state after the given number of consecutive reopens.
+func (b *BackoffPolicy) timeout(reopens uint32) time.Duration {
+	timeout := float64(b.Base) * math.Pow(b.Multiplier, float64(reopens))
+	if b.Cap > 0 {
+		timeout = math.Min(timeout, float64(b.Cap))
+	}
+	timeout = math.Min(timeout, math.MaxInt64/2)
+
+	if b.Jitter > 0 {
+		timeout += timeout * b.Jitter * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(timeout)
+}
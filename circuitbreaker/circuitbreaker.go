@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -19,6 +20,8 @@ const (
 	StateClosed State = iota
 	StateHalfOpen
 	StateOpen
+	StateForcedOpen
+	StateDisabled
 )
 
 var (
@@ -37,6 +40,10 @@ func (s State) String() string {
 		return "half-open"
 	case StateOpen:
 		return "open"
+	case StateForcedOpen:
+		return "forced-open"
+	case StateDisabled:
+		return "disabled"
 	default:
 		return fmt.Sprintf("unknown state: %d", s)
 	}
@@ -46,12 +53,33 @@ func (s State) String() string {
 // CircuitBreaker clears the internal Counts either
 // on the change of the state or at the closed-state intervals.
 // Counts ignores the results of the requests sent before clearing.
+// WindowRequests, WindowFailures and WindowSlowCalls hold the outcomes currently in the sliding window,
+// which is only cleared on the change of the state.
 type Counts struct {
 	Requests             uint32
 	TotalSuccesses       uint32
 	TotalFailures        uint32
 	ConsecutiveSuccesses uint32
 	ConsecutiveFailures  uint32
+	WindowRequests       uint32
+	WindowFailures       uint32
+	WindowSlowCalls      uint32
+}
+
+// FailureRate returns the percentage of failed requests in the sliding window.
+func (c *Counts) FailureRate() float64 {
+	if c.WindowRequests == 0 {
+		return 0
+	}
+	return float64(c.WindowFailures) / float64(c.WindowRequests) * 100
+}
+
+// SlowCallRate returns the percentage of slow requests in the sliding window.
+func (c *Counts) SlowCallRate() float64 {
+	if c.WindowRequests == 0 {
+		return 0
+	}
+	return float64(c.WindowSlowCalls) / float64(c.WindowRequests) * 100
 }
 
 func (c *Counts) onRequest() {
@@ -97,7 +125,38 @@ func (c *Counts) clear() {
 // ReadyToTrip is called with a copy of Counts whenever a request fails in the closed state.
 // If ReadyToTrip returns true, the CircuitBreaker will be placed into the open state.
 // If ReadyToTrip is nil, default ReadyToTrip is used.
-// Default ReadyToTrip returns true when the number of consecutive failures is more than 5.
+// Default ReadyToTrip returns true when the number of consecutive failures is more than 5,
+// or never when a sliding window is configured.
+//
+// SlidingWindowType selects how the outcomes of the requests in the closed state are aggregated.
+// SlidingWindowCountBased keeps the last SlidingWindowSize requests and
+// SlidingWindowTimeBased keeps the requests of the last SlidingWindowSize seconds.
+// If SlidingWindowType is SlidingWindowNone, no sliding window is kept.
+// If SlidingWindowSize is 0, the window size is set to 100.
+//
+// FailureRateThreshold is the percentage of failed requests in the sliding window
+// at or above which the CircuitBreaker is placed into the open state.
+// If FailureRateThreshold is less than or equal to 0, the threshold is set to 50 percent.
+//
+// MinimumNumberOfCalls is the number of requests the sliding window must hold
+// before the failure rate is evaluated.
+// If MinimumNumberOfCalls is 0, the minimum is set to 10 requests.
+//
+// SlowCallDurationThreshold is the duration above which a request is counted as slow,
+// whether it succeeds or fails.
+// If SlowCallDurationThreshold is less than or equal to 0, no request is counted as slow.
+//
+// SlowCallRateThreshold is the percentage of slow requests in the sliding window
+// at or above which the CircuitBreaker is placed into the open state.
+// If SlowCallRateThreshold is less than or equal to 0, the threshold is set to 100 percent.
+// Slow calls are only recorded when a sliding window is configured.
+//
+// Backoff grows the period of the open state each time a half-open probe fails,
+// and resets it once the CircuitBreaker closes.
+// If Backoff is nil, every open state lasts Timeout.
+//
+// MetricsOnly keeps the state machine running and OnStateChange firing,
+// but never rejects a request, so that thresholds can be observed before they are enforced.
 //
 // OnStateChange is called whenever the state of the CircuitBreaker changes.
 //
@@ -105,6 +164,11 @@ func (c *Counts) clear() {
 // If IsSuccessful returns true, the error is counted as a success.
 // Otherwise the error is counted as a failure.
 // If IsSuccessful is nil, default IsSuccessful is used, which returns false for all non-nil errors.
+//
+// RetryAfter is called with the error of a failed request.
+// If RetryAfter returns true, the CircuitBreaker opens right away for the returned period
+// instead of its Timeout or Backoff, e.g. when the upstream answered with a Retry-After header.
+// If RetryAfter is nil, failures only trip the CircuitBreaker through its trip policy.
 type Settings struct {
 	Name          string
 	MaxRequests   uint32
@@ -113,6 +177,19 @@ type Settings struct {
 	ReadyToTrip   func(counts Counts) bool
 	OnStateChange func(name string, from State, to State)
 	IsSuccessful  func(err error) bool
+	RetryAfter    func(err error) (time.Duration, bool)
+
+	SlidingWindowType    SlidingWindowType
+	SlidingWindowSize    uint32
+	FailureRateThreshold float64
+	MinimumNumberOfCalls uint32
+
+	SlowCallDurationThreshold time.Duration
+	SlowCallRateThreshold     float64
+
+	Backoff *BackoffPolicy
+
+	MetricsOnly bool
 }
 
 // CircuitBreaker is a state machine to prevent sending requests that are likely to fail.
@@ -123,13 +200,28 @@ type CircuitBreaker struct {
 	timeout       time.Duration
 	readyToTrip   func(counts Counts) bool
 	isSuccessful  func(err error) bool
+	retryAfter    func(err error) (time.Duration, bool)
 	onStateChange func(name string, from State, to State)
 
+	window                *slidingWindow
+	failureRateThreshold  float64
+	minimumNumberOfCalls  uint32
+	slowCallDuration      time.Duration
+	slowCallRateThreshold float64
+	backoff               *BackoffPolicy
+
 	mutex      sync.Mutex
 	state      State
 	generation uint64
 	counts     Counts
 	expiry     time.Time
+	reopens    uint32
+	// openFor overrides the period of the next open state when it is greater than 0
+	openFor time.Duration
+
+	openTimeout atomic.Int64
+	metricsOnly atomic.Bool
+	tripReason  atomic.Pointer[TripReason]
 }
 
 // TwoStepCircuitBreaker is like CircuitBreaker but instead of surrounding a function
@@ -164,10 +256,45 @@ func NewCircuitBreaker(st Settings) *CircuitBreaker {
 		cb.timeout = st.Timeout
 	}
 
-	if st.ReadyToTrip == nil {
-		cb.readyToTrip = defaultReadyToTrip
-	} else {
+	if st.Backoff != nil {
+		cb.backoff = st.Backoff.withDefaults(cb.timeout)
+	}
+	cb.openTimeout.Store(int64(cb.timeout))
+	cb.metricsOnly.Store(st.MetricsOnly)
+
+	if st.SlidingWindowType != SlidingWindowNone {
+		size := st.SlidingWindowSize
+		if size == 0 {
+			size = defaultSlidingWindowSize
+		}
+		cb.window = newSlidingWindow(st.SlidingWindowType, size)
+
+		if st.FailureRateThreshold <= 0 {
+			cb.failureRateThreshold = defaultFailureRateThreshold
+		} else {
+			cb.failureRateThreshold = st.FailureRateThreshold
+		}
+
+		if st.MinimumNumberOfCalls == 0 {
+			cb.minimumNumberOfCalls = defaultMinimumNumberOfCalls
+		} else {
+			cb.minimumNumberOfCalls = st.MinimumNumberOfCalls
+		}
+
+		cb.slowCallDuration = st.SlowCallDurationThreshold
+		if st.SlowCallRateThreshold <= 0 {
+			cb.slowCallRateThreshold = defaultSlowCallRateThreshold
+		} else {
+			cb.slowCallRateThreshold = st.SlowCallRateThreshold
+		}
+	}
+
+	if st.ReadyToTrip != nil {
 		cb.readyToTrip = st.ReadyToTrip
+	} else if cb.window != nil {
+		cb.readyToTrip = neverReadyToTrip
+	} else {
+		cb.readyToTrip = defaultReadyToTrip
 	}
 
 	if st.IsSuccessful == nil {
@@ -175,6 +302,7 @@ func NewCircuitBreaker(st Settings) *CircuitBreaker {
 	} else {
 		cb.isSuccessful = st.IsSuccessful
 	}
+	cb.retryAfter = st.RetryAfter
 
 	cb.toNewGeneration(time.Now())
 
@@ -195,6 +323,10 @@ func defaultReadyToTrip(counts Counts) bool {
 	return counts.ConsecutiveFailures > 5
 }
 
+func neverReadyToTrip(_ Counts) bool {
+	return false
+}
+
 func defaultIsSuccessful(err error) bool {
 	return err == nil
 }
@@ -214,12 +346,87 @@ func (cb *CircuitBreaker) State() State {
 	return state
 }
 
+// OpenTimeout returns the period of the current or the most recent open state,
+// including backoff and jitter. It is safe to call from OnStateChange.
+func (cb *CircuitBreaker) OpenTimeout() time.Duration {
+	return time.Duration(cb.openTimeout.Load())
+}
+
+// TripReason returns the reason of the last trip, or a zero TripReason if the CircuitBreaker never tripped.
+// It does not lock the CircuitBreaker, so it can be called from OnStateChange.
+func (cb *CircuitBreaker) TripReason() TripReason {
+	if reason := cb.tripReason.Load(); reason != nil {
+		return *reason
+	}
+	return TripReason{}
+}
+
 // Counts returns internal counters
 func (cb *CircuitBreaker) Counts() Counts {
 	cb.mutex.Lock()
 	defer cb.mutex.Unlock()
 
-	return cb.counts
+	return cb.snapshot(time.Now())
+}
+
+// Trip places the CircuitBreaker into the open state as if ReadyToTrip returned true.
+func (cb *CircuitBreaker) Trip() {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	now := time.Now()
+	cb.trip(StateOpen, TripReason{Cause: TripCauseManual, Counts: cb.snapshot(now)}, now)
+}
+
+// ForceOpen places the CircuitBreaker into the forced-open state.
+// Unlike Trip, the forced-open state never times out into half-open;
+// every request is rejected until Reset is called.
+func (cb *CircuitBreaker) ForceOpen() {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	now := time.Now()
+	cb.trip(StateForcedOpen, TripReason{Cause: TripCauseForced, Counts: cb.snapshot(now)}, now)
+}
+
+// Disable places the CircuitBreaker into the disabled state.
+// A disabled CircuitBreaker allows every request and keeps recording its counts,
+// but never changes state on its own until Reset is called.
+func (cb *CircuitBreaker) Disable() {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	cb.setState(StateDisabled, time.Now())
+}
+
+// SetMetricsOnly turns the metrics-only mode of the CircuitBreaker on or off.
+func (cb *CircuitBreaker) SetMetricsOnly(enabled bool) {
+	cb.metricsOnly.Store(enabled)
+}
+
+// MetricsOnly reports whether the CircuitBreaker is in metrics-only mode.
+// It is safe to call from OnStateChange.
+func (cb *CircuitBreaker) MetricsOnly() bool {
+	return cb.metricsOnly.Load()
+}
+
+// Reset places the CircuitBreaker into the closed state and clears its counts, sliding window and backoff.
+// Reset also leaves the forced-open and disabled states.
+func (cb *CircuitBreaker) Reset() {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	now := time.Now()
+	cb.reopens = 0
+	if cb.state == StateClosed {
+		cb.toNewGeneration(now)
+		if cb.window != nil {
+			cb.window.clear()
+		}
+		return
+	}
+
+	cb.setState(StateClosed, now)
 }
 
 // Execute runs the given request if the CircuitBreaker accepts it.
@@ -233,16 +440,26 @@ func (cb *CircuitBreaker) Execute(req func() (interface{}, error)) (interface{},
 		return nil, err
 	}
 
+	start := time.Now()
 	defer func() {
 		e := recover()
 		if e != nil {
-			cb.afterRequest(generation, false)
+			cb.afterRequest(generation, false, 0, time.Since(start))
 			panic(e)
 		}
 	}()
 
 	result, err := req()
-	cb.afterRequest(generation, cb.isSuccessful(err))
+	success := cb.isSuccessful(err)
+
+	var retryAfter time.Duration
+	if !success && cb.retryAfter != nil {
+		if period, ok := cb.retryAfter(err); ok {
+			retryAfter = period
+		}
+	}
+
+	cb.afterRequest(generation, success, retryAfter, time.Since(start))
 	return result, err
 }
 
@@ -261,17 +478,23 @@ func (tscb *TwoStepCircuitBreaker) Counts() Counts {
 	return tscb.cb.Counts()
 }
 
+// OpenTimeout returns the period of the current or the most recent open state of the TwoStepCircuitBreaker.
+func (tscb *TwoStepCircuitBreaker) OpenTimeout() time.Duration {
+	return tscb.cb.OpenTimeout()
+}
+
 // Allow checks if a new request can proceed. It returns a callback that should be used to
 // register the success or failure in a separate step. If the circuit breaker doesn't allow
-// requests, it returns an error.
+// requests, it returns an error. The duration of the request is measured from the call to Allow.
 func (tscb *TwoStepCircuitBreaker) Allow() (done func(success bool), err error) {
 	generation, err := tscb.cb.beforeRequest()
 	if err != nil {
 		return nil, err
 	}
 
+	start := time.Now()
 	return func(success bool) {
-		tscb.cb.afterRequest(generation, success)
+		tscb.cb.afterRequest(generation, success, 0, time.Since(start))
 	}, nil
 }
 
@@ -282,9 +505,14 @@ func (cb *CircuitBreaker) beforeRequest() (uint64, error) {
 	now := time.Now()
 	state, generation := cb.currentState(now)
 
-	if state == StateOpen {
+	switch {
+	case state == StateForcedOpen:
+		return generation, ErrOpenState
+	case state == StateDisabled || cb.metricsOnly.Load():
+		// every request is allowed
+	case state == StateOpen:
 		return generation, ErrOpenState
-	} else if state == StateHalfOpen && cb.counts.Requests >= cb.maxRequests {
+	case state == StateHalfOpen && cb.counts.Requests >= cb.maxRequests:
 		return generation, ErrTooManyRequests
 	}
 
@@ -292,7 +520,7 @@ func (cb *CircuitBreaker) beforeRequest() (uint64, error) {
 	return generation, nil
 }
 
-func (cb *CircuitBreaker) afterRequest(before uint64, success bool) {
+func (cb *CircuitBreaker) afterRequest(before uint64, success bool, retryAfter time.Duration, duration time.Duration) {
 	cb.mutex.Lock()
 	defer cb.mutex.Unlock()
 
@@ -302,37 +530,98 @@ func (cb *CircuitBreaker) afterRequest(before uint64, success bool) {
 		return
 	}
 
+	slow := cb.slowCallDuration > 0 && duration > cb.slowCallDuration
 	if success {
-		cb.onSuccess(state, now)
+		cb.onSuccess(state, now, slow)
 	} else {
-		cb.onFailure(state, now)
+		cb.onFailure(state, now, slow, retryAfter)
 	}
 }
 
-func (cb *CircuitBreaker) onSuccess(state State, now time.Time) {
+func (cb *CircuitBreaker) onSuccess(state State, now time.Time, slow bool) {
 	switch state {
 	case StateClosed:
 		cb.counts.onSuccess()
+		if cb.window != nil {
+			cb.window.record(now, true, slow)
+			if counts := cb.snapshot(now); cb.isSlowCallRateExceeded(counts) {
+				cb.trip(StateOpen, TripReason{Cause: TripCauseSlowCallRate, Counts: counts, Threshold: cb.slowCallRateThreshold}, now)
+			}
+		}
 	case StateHalfOpen:
 		cb.counts.onSuccess()
 		if cb.counts.ConsecutiveSuccesses >= cb.maxRequests {
 			cb.setState(StateClosed, now)
 		}
+	case StateDisabled:
+		cb.counts.onSuccess()
+		if cb.window != nil {
+			cb.window.record(now, true, slow)
+		}
 	}
 }
 
-func (cb *CircuitBreaker) onFailure(state State, now time.Time) {
+func (cb *CircuitBreaker) onFailure(state State, now time.Time, slow bool, retryAfter time.Duration) {
 	switch state {
 	case StateClosed:
 		cb.counts.onFailure()
-		if cb.readyToTrip(cb.counts) {
-			cb.setState(StateOpen, now)
+		if cb.window != nil {
+			cb.window.record(now, false, slow)
+		}
+		counts := cb.snapshot(now)
+		switch {
+		case retryAfter > 0:
+			cb.openFor = retryAfter
+			cb.trip(StateOpen, TripReason{Cause: TripCauseRetryAfter, Counts: counts}, now)
+		case cb.readyToTrip(counts):
+			cb.trip(StateOpen, TripReason{Cause: TripCauseReadyToTrip, Counts: counts}, now)
+		case cb.isFailureRateExceeded(counts):
+			cb.trip(StateOpen, TripReason{Cause: TripCauseFailureRate, Counts: counts, Threshold: cb.failureRateThreshold}, now)
+		case cb.isSlowCallRateExceeded(counts):
+			cb.trip(StateOpen, TripReason{Cause: TripCauseSlowCallRate, Counts: counts, Threshold: cb.slowCallRateThreshold}, now)
 		}
 	case StateHalfOpen:
-		cb.setState(StateOpen, now)
+		cb.counts.onFailure()
+		if retryAfter > 0 {
+			cb.openFor = retryAfter
+			cb.trip(StateOpen, TripReason{Cause: TripCauseRetryAfter, Counts: cb.snapshot(now)}, now)
+			return
+		}
+		cb.trip(StateOpen, TripReason{Cause: TripCauseHalfOpenFailure, Counts: cb.snapshot(now)}, now)
+	case StateDisabled:
+		cb.counts.onFailure()
+		if cb.window != nil {
+			cb.window.record(now, false, slow)
+		}
 	}
 }
 
+// snapshot returns a copy of the internal Counts together with the outcomes in the sliding window.
+func (cb *CircuitBreaker) snapshot(now time.Time) Counts {
+	counts := cb.counts
+	if cb.window != nil {
+		total := cb.window.aggregate(now)
+		counts.WindowRequests = total.requests
+		counts.WindowFailures = total.failures
+		counts.WindowSlowCalls = total.slowCalls
+	}
+	return counts
+}
+
+func (cb *CircuitBreaker) isFailureRateExceeded(counts Counts) bool {
+	if cb.window == nil || counts.WindowRequests < cb.minimumNumberOfCalls {
+		return false
+	}
+	return counts.FailureRate() >= cb.failureRateThreshold
+}
+
+func (cb *CircuitBreaker) isSlowCallRateExceeded(counts Counts) bool {
+	if cb.window == nil || cb.slowCallDuration <= 0 || counts.WindowRequests < cb.minimumNumberOfCalls {
+		return false
+	}
+	return counts.SlowCallRate() >= cb.slowCallRateThreshold
+}
+
 func (cb *CircuitBreaker) currentState(now time.Time) (State, uint64) {
 	switch cb.state {
 	case StateClosed:
@@ -347,6 +636,16 @@ func (cb *CircuitBreaker) currentState(now time.Time) (State, uint64) {
 	return cb.state, cb.generation
 }
 
+// trip records the reason before entering the open or forced-open state, so OnStateChange can read it
+func (cb *CircuitBreaker) trip(state State, reason TripReason, now time.Time) {
+	if cb.state == state {
+		return
+	}
+
+	cb.tripReason.Store(&reason)
+	cb.setState(state, now)
+}
+
 func (cb *CircuitBreaker) setState(state State, now time.Time) {
 	if cb.state == state {
 		return
@@ -355,7 +654,17 @@ func (cb *CircuitBreaker) setState(state State, now time.Time) {
 	prev := cb.state
 	cb.state = state
 
+	switch {
+	case state == StateClosed:
+		cb.reopens = 0
+	case prev == StateHalfOpen && state == StateOpen:
+		cb.reopens++
+	}
+
 	cb.toNewGeneration(now)
+	if cb.window != nil {
+		cb.window.clear()
+	}
 
 	if cb.onStateChange != nil {
 		cb.onStateChange(cb.name, prev, state)
@@ -375,8 +684,17 @@ func (cb *CircuitBreaker) toNewGeneration(now time.Time) {
 			cb.expiry = now.Add(cb.interval)
 		}
 	case StateOpen:
-		cb.expiry = now.Add(cb.timeout)
-	default: // StateHalfOpen
+		timeout := cb.timeout
+		if cb.backoff != nil {
+			timeout = cb.backoff.timeout(cb.reopens)
+		}
+		if cb.openFor > 0 {
+			timeout = cb.openFor
+			cb.openFor = 0
+		}
+		cb.openTimeout.Store(int64(timeout))
+		cb.expiry = now.Add(timeout)
+	default: // StateHalfOpen, StateForcedOpen, StateDisabled
 		cb.expiry = zero
 	}
 }
@@ -0,0 +1,403 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func Test_circuitBreaker_slidingWindow(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	type args struct {
+		settings Settings
+		outcomes []error
+		delay    time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+		want State
+	}{
+		{
+			name: "Count_based_below_minimum_calls",
+			args: args{
+				settings: Settings{
+					SlidingWindowType:    SlidingWindowCountBased,
+					SlidingWindowSize:    10,
+					FailureRateThreshold: 50,
+					MinimumNumberOfCalls: 5,
+				},
+				outcomes: []error{errFailed, errFailed, errFailed, errFailed},
+			},
+			want: StateClosed,
+		},
+		{
+			name: "Count_based_flaky_endpoint_trips",
+			args: args{
+				settings: Settings{
+					SlidingWindowType:    SlidingWindowCountBased,
+					SlidingWindowSize:    10,
+					FailureRateThreshold: 40,
+					MinimumNumberOfCalls: 5,
+				},
+				outcomes: []error{nil, errFailed, nil, errFailed, nil, nil, errFailed, nil, errFailed},
+			},
+			want: StateOpen,
+		},
+		{
+			name: "Count_based_old_failures_evicted",
+			args: args{
+				settings: Settings{
+					SlidingWindowType:    SlidingWindowCountBased,
+					SlidingWindowSize:    4,
+					FailureRateThreshold: 50,
+					MinimumNumberOfCalls: 4,
+				},
+				outcomes: []error{errFailed, nil, nil, nil, nil, nil, nil, errFailed},
+			},
+			want: StateClosed,
+		},
+		{
+			name: "Time_based_flaky_endpoint_trips",
+			args: args{
+				settings: Settings{
+					SlidingWindowType:    SlidingWindowTimeBased,
+					SlidingWindowSize:    60,
+					FailureRateThreshold: 40,
+					MinimumNumberOfCalls: 5,
+				},
+				outcomes: []error{nil, errFailed, nil, nil, errFailed},
+			},
+			want: StateOpen,
+		},
+		{
+			name: "Slow_successful_calls_trip",
+			args: args{
+				settings: Settings{
+					SlidingWindowType:         SlidingWindowCountBased,
+					SlidingWindowSize:         10,
+					MinimumNumberOfCalls:      3,
+					SlowCallDurationThreshold: time.Millisecond,
+					SlowCallRateThreshold:     50,
+				},
+				outcomes: []error{nil, nil, nil},
+				delay:    5 * time.Millisecond,
+			},
+			want: StateOpen,
+		},
+		{
+			name: "Fast_calls_do_not_trip_on_slow_call_rate",
+			args: args{
+				settings: Settings{
+					SlidingWindowType:         SlidingWindowCountBased,
+					SlidingWindowSize:         10,
+					MinimumNumberOfCalls:      3,
+					SlowCallDurationThreshold: time.Second,
+					SlowCallRateThreshold:     50,
+				},
+				outcomes: []error{nil, nil, nil},
+			},
+			want: StateClosed,
+		},
+		{
+			name: "Custom_ready_to_trip_still_applies",
+			args: args{
+				settings: Settings{
+					SlidingWindowType:    SlidingWindowCountBased,
+					FailureRateThreshold: 90,
+					ReadyToTrip: func(counts Counts) bool {
+						return counts.ConsecutiveFailures >= 2
+					},
+				},
+				outcomes: []error{errFailed, errFailed},
+			},
+			want: StateOpen,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb := NewCircuitBreaker(tt.args.settings)
+			for _, outcome := range tt.args.outcomes {
+				_, _ = cb.Execute(func() (interface{}, error) {
+					time.Sleep(tt.args.delay)
+					return nil, outcome
+				})
+			}
+			if got := cb.State(); got != tt.want {
+				t.Errorf("State() = %v, want %v (counts %+v)", got, tt.want, cb.Counts())
+			}
+		})
+	}
+}
+
+func Test_slidingWindow_timeBasedExpiry(t *testing.T) {
+	w := newSlidingWindow(SlidingWindowTimeBased, 10)
+	start := time.Unix(1000, 0)
+
+	w.record(start, false, false)
+	w.record(start.Add(5*time.Second), true, false)
+
+	if got := w.aggregate(start.Add(9 * time.Second)); got.requests != 2 || got.failures != 1 {
+		t.Errorf("aggregate() = %+v, want 2 requests and 1 failure", got)
+	}
+	if got := w.aggregate(start.Add(10 * time.Second)); got.requests != 1 || got.failures != 0 {
+		t.Errorf("aggregate() = %+v, want 1 request and 0 failures", got)
+	}
+}
+
+func Test_circuitBreaker_backoff(t *testing.T) {
+	cb := NewCircuitBreaker(Settings{
+		Timeout:     time.Millisecond,
+		ReadyToTrip: func(counts Counts) bool { return true },
+		Backoff: &BackoffPolicy{
+			Multiplier: 3,
+			Cap:        5 * time.Millisecond,
+		},
+	})
+	fail := func() {
+		_, _ = cb.Execute(func() (interface{}, error) {
+			return nil, errors.New("failed")
+		})
+	}
+
+	want := []time.Duration{time.Millisecond, 3 * time.Millisecond, 5 * time.Millisecond, 5 * time.Millisecond}
+	for i, timeout := range want {
+		fail()
+		if got := cb.OpenTimeout(); got != timeout {
+			t.Errorf("reopen %d: OpenTimeout() = %v, want %v", i, got, timeout)
+		}
+		time.Sleep(cb.OpenTimeout() + time.Millisecond)
+		if got := cb.State(); got != StateHalfOpen {
+			t.Fatalf("reopen %d: State() = %v, want %v", i, got, StateHalfOpen)
+		}
+	}
+
+	_, _ = cb.Execute(func() (interface{}, error) {
+		return nil, nil
+	})
+	fail()
+	if got := cb.OpenTimeout(); got != time.Millisecond {
+		t.Errorf("after close: OpenTimeout() = %v, want %v", got, time.Millisecond)
+	}
+}
+
+func Test_backoffPolicy_jitter(t *testing.T) {
+	policy := (&BackoffPolicy{Base: time.Second, Jitter: 0.5}).withDefaults(time.Minute)
+	for i := 0; i < 100; i++ {
+		if got := policy.timeout(1); got < time.Second || got > 3*time.Second {
+			t.Fatalf("timeout() = %v, want between 1s and 3s", got)
+		}
+	}
+}
+
+func Test_circuitBreaker_overrides(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	type args struct {
+		settings Settings
+		override func(cb *CircuitBreaker)
+		outcomes []error
+	}
+	tests := []struct {
+		name         string
+		args         args
+		want         State
+		wantRejected int
+		wantRequests uint32
+	}{
+		{
+			name: "Forced_open_rejects_and_never_half_opens",
+			args: args{
+				settings: Settings{Timeout: time.Millisecond},
+				override: func(cb *CircuitBreaker) {
+					cb.ForceOpen()
+					time.Sleep(2 * time.Millisecond)
+				},
+				outcomes: []error{nil, nil},
+			},
+			want:         StateForcedOpen,
+			wantRejected: 2,
+		},
+		{
+			name: "Disabled_allows_and_records",
+			args: args{
+				settings: Settings{ReadyToTrip: func(counts Counts) bool { return true }},
+				override: func(cb *CircuitBreaker) { cb.Disable() },
+				outcomes: []error{errFailed, errFailed, nil},
+			},
+			want:         StateDisabled,
+			wantRequests: 3,
+		},
+		{
+			name: "Metrics_only_trips_without_rejecting",
+			args: args{
+				settings: Settings{
+					ReadyToTrip: func(counts Counts) bool { return true },
+					MetricsOnly: true,
+				},
+				outcomes: []error{errFailed, nil, nil},
+			},
+			want:         StateOpen,
+			wantRequests: 2,
+		},
+		{
+			name: "Reset_leaves_forced_open",
+			args: args{
+				override: func(cb *CircuitBreaker) {
+					cb.ForceOpen()
+					cb.Reset()
+				},
+				outcomes: []error{nil},
+			},
+			want:         StateClosed,
+			wantRequests: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb := NewCircuitBreaker(tt.args.settings)
+			if tt.args.override != nil {
+				tt.args.override(cb)
+			}
+			rejected := 0
+			for _, outcome := range tt.args.outcomes {
+				_, err := cb.Execute(func() (interface{}, error) {
+					return nil, outcome
+				})
+				if errors.Is(err, ErrOpenState) || errors.Is(err, ErrTooManyRequests) {
+					rejected++
+				}
+			}
+			if got := cb.State(); got != tt.want {
+				t.Errorf("State() = %v, want %v", got, tt.want)
+			}
+			if rejected != tt.wantRejected {
+				t.Errorf("rejected = %d, want %d", rejected, tt.wantRejected)
+			}
+			if got := cb.Counts().Requests; got != tt.wantRequests {
+				t.Errorf("Counts().Requests = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func Test_circuitBreaker_tripReason(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	type args struct {
+		settings Settings
+		override func(cb *CircuitBreaker)
+		outcomes []error
+	}
+	tests := []struct {
+		name string
+		args args
+		want TripReason
+	}{
+		{
+			name: "Never_tripped",
+			args: args{
+				outcomes: []error{nil, errFailed},
+			},
+			want: TripReason{},
+		},
+		{
+			name: "Consecutive_failures",
+			args: args{
+				settings: Settings{
+					ReadyToTrip: func(counts Counts) bool { return counts.ConsecutiveFailures >= 2 },
+				},
+				outcomes: []error{nil, errFailed, errFailed},
+			},
+			want: TripReason{
+				Cause:  TripCauseReadyToTrip,
+				Counts: Counts{Requests: 3, TotalSuccesses: 1, TotalFailures: 2, ConsecutiveFailures: 2},
+			},
+		},
+		{
+			name: "Failure_rate",
+			args: args{
+				settings: Settings{
+					SlidingWindowType:    SlidingWindowCountBased,
+					SlidingWindowSize:    4,
+					FailureRateThreshold: 50,
+					MinimumNumberOfCalls: 4,
+				},
+				outcomes: []error{nil, errFailed, nil, errFailed},
+			},
+			want: TripReason{
+				Cause: TripCauseFailureRate,
+				Counts: Counts{
+					Requests:            4,
+					TotalSuccesses:      2,
+					TotalFailures:       2,
+					ConsecutiveFailures: 1,
+					WindowRequests:      4,
+					WindowFailures:      2,
+				},
+				Threshold: 50,
+			},
+		},
+		{
+			name: "Forced_open",
+			args: args{
+				override: func(cb *CircuitBreaker) { cb.ForceOpen() },
+			},
+			want: TripReason{Cause: TripCauseForced},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb := NewCircuitBreaker(tt.args.settings)
+			if tt.args.override != nil {
+				tt.args.override(cb)
+			}
+			for _, outcome := range tt.args.outcomes {
+				cb.Execute(func() (interface{}, error) {
+					return nil, outcome
+				})
+			}
+			if got := cb.TripReason(); got != tt.want {
+				t.Errorf("TripReason() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_circuitBreaker_retryAfter(t *testing.T) {
+	errRetryAfter := errors.New("retry after")
+	cb := NewCircuitBreaker(Settings{
+		Timeout: time.Minute,
+		RetryAfter: func(err error) (time.Duration, bool) {
+			return 30 * time.Second, errors.Is(err, errRetryAfter)
+		},
+	})
+
+	cb.Execute(func() (interface{}, error) {
+		return nil, errors.New("failed")
+	})
+	if got := cb.State(); got != StateClosed {
+		t.Fatalf("State() after a failure = %v, want %v", got, StateClosed)
+	}
+
+	cb.Execute(func() (interface{}, error) {
+		return nil, errRetryAfter
+	})
+	if got := cb.State(); got != StateOpen {
+		t.Fatalf("State() after a retry after = %v, want %v", got, StateOpen)
+	}
+	if got := cb.OpenTimeout(); got != 30*time.Second {
+		t.Errorf("OpenTimeout() = %v, want %v", got, 30*time.Second)
+	}
+	if got := cb.TripReason().Cause; got != TripCauseRetryAfter {
+		t.Errorf("TripReason().Cause = %v, want %v", got, TripCauseRetryAfter)
+	}
+
+	// the next open state falls back to the timeout
+	cb.Reset()
+	cb.Trip()
+	if got := cb.OpenTimeout(); got != time.Minute {
+		t.Errorf("OpenTimeout() after Trip = %v, want %v", got, time.Minute)
+	}
+}
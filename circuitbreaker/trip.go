@@ -0,0 +1,29 @@
+package circuitbreaker
+
+// TripCause tells why a CircuitBreaker entered the open or forced-open state.
+type TripCause string
+
+const (
+	// TripCauseReadyToTrip is a trip decided by the ReadyToTrip of the Settings
+	TripCauseReadyToTrip TripCause = "ready-to-trip"
+	// TripCauseFailureRate is a trip on the failure rate of the sliding window
+	TripCauseFailureRate TripCause = "failure-rate"
+	// TripCauseSlowCallRate is a trip on the slow call rate of the sliding window
+	TripCauseSlowCallRate TripCause = "slow-call-rate"
+	// TripCauseHalfOpenFailure is a failed request in the half-open state
+	TripCauseHalfOpenFailure TripCause = "half-open-failure"
+	// TripCauseRetryAfter is a failed request for which the RetryAfter of the Settings returned a period
+	TripCauseRetryAfter TripCause = "retry-after"
+	// TripCauseManual is a call to Trip
+	TripCauseManual TripCause = "manual"
+	// TripCauseForced is a call to ForceOpen
+	TripCauseForced TripCause = "forced"
+)
+
+// TripReason holds the cause of a trip and the Counts it was decided on.
+// Threshold is the configured rate for TripCauseFailureRate and TripCauseSlowCallRate, and 0 otherwise.
+type TripReason struct {
+	Cause     TripCause
+	Counts    Counts
+	Threshold float64
+}
@@ -0,0 +1,138 @@
+package circuitbreaker
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SlidingWindowType is a type that represents how CircuitBreaker aggregates the outcomes of recent requests.
+type SlidingWindowType int
+
+// These constants are sliding window types of CircuitBreaker.
+const (
+	SlidingWindowNone SlidingWindowType = iota
+	SlidingWindowCountBased
+	SlidingWindowTimeBased
+)
+
+const defaultSlidingWindowSize = 100
+const defaultFailureRateThreshold = 50
+const defaultMinimumNumberOfCalls = 10
+const defaultSlowCallRateThreshold = 100
+
+// String implements stringer interface.
+func (t SlidingWindowType) String() string {
+	switch t {
+	case SlidingWindowNone:
+		return "none"
+	case SlidingWindowCountBased:
+		return "count"
+	case SlidingWindowTimeBased:
+		return "time"
+	default:
+		return fmt.Sprintf("unknown sliding window type: %d", t)
+	}
+}
+
+// ParseSlidingWindowType returns the SlidingWindowType named by s.
+// An empty string is parsed as SlidingWindowNone.
+func ParseSlidingWindowType(s string) (SlidingWindowType, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "none":
+		return SlidingWindowNone, nil
+	case "count", "count-based":
+		return SlidingWindowCountBased, nil
+	case "time", "time-based":
+		return SlidingWindowTimeBased, nil
+	default:
+		return SlidingWindowNone, fmt.Errorf("unknown sliding window type: %q", s)
+	}
+}
+
+// bucket holds the outcomes of the requests recorded in one slot of a slidingWindow.
+// For a time-based window, epoch is the unix second the bucket belongs to.
+type bucket struct {
+	epoch     int64
+	requests  uint32
+	failures  uint32
+	slowCalls uint32
+}
+
+func (b *bucket) add(o bucket) {
+	b.requests += o.requests
+	b.failures += o.failures
+	b.slowCalls += o.slowCalls
+}
+
+func (b *bucket) sub(o bucket) {
+	b.requests -= o.requests
+	b.failures -= o.failures
+	b.slowCalls -= o.slowCalls
+}
+
+// slidingWindow is a ring of buckets.
+// A count-based window keeps one bucket per request and evicts the oldest request when full.
+// A time-based window keeps one bucket per second and ignores buckets older than its size.
+type slidingWindow struct {
+	windowType SlidingWindowType
+	buckets    []bucket
+	head       int
+	total      bucket
+}
+
+func newSlidingWindow(windowType SlidingWindowType, size uint32) *slidingWindow {
+	return &slidingWindow{
+		windowType: windowType,
+		buckets:    make([]bucket, size),
+	}
+}
+
+func (w *slidingWindow) record(now time.Time, success, slow bool) {
+	outcome := bucket{requests: 1}
+	if !success {
+		outcome.failures = 1
+	}
+	if slow {
+		outcome.slowCalls = 1
+	}
+
+	switch w.windowType {
+	case SlidingWindowCountBased:
+		w.total.sub(w.buckets[w.head])
+		w.buckets[w.head] = outcome
+		w.total.add(outcome)
+		w.head = (w.head + 1) % len(w.buckets)
+	case SlidingWindowTimeBased:
+		epoch := now.Unix()
+		b := &w.buckets[epoch%int64(len(w.buckets))]
+		if b.epoch != epoch {
+			*b = bucket{epoch: epoch}
+		}
+		b.add(outcome)
+	}
+}
+
+// aggregate returns the sum of the buckets that are still inside the window at now.
+func (w *slidingWindow) aggregate(now time.Time) bucket {
+	if w.windowType != SlidingWindowTimeBased {
+		return w.total
+	}
+
+	var total bucket
+	oldest := now.Unix() - int64(len(w.buckets))
+	for _, b := range w.buckets {
+		if b.epoch > oldest {
+			total.add(b)
+		}
+	}
+	return total
+}
+
+func (w *slidingWindow) clear() {
+	for i := range w.buckets {
+		w.buckets[i] = bucket{}
+	}
+	w.head = 0
+	w.total = bucket{}
+}
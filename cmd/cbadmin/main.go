@@ -0,0 +1,81 @@
+// Command cbadmin calls the CircuitBreakerAdmin service of a running sidecar.
+//
+// Usage:
+//
+//	cbadmin [-addr host:port] graph [-format dot|mermaid|json]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/daffarg/distributed-cascading-cb/service"
+	"github.com/daffarg/distributed-cascading-cb/transport/client"
+	"github.com/daffarg/distributed-cascading-cb/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func main() {
+	addr := flag.String(
+		"addr",
+		fmt.Sprintf("%s:%s", util.GetEnv("SERVICE_IP", "127.0.0.1"), util.GetEnv("SERVICE_PORT", "5320")),
+		"address of the sidecar",
+	)
+	timeout := flag.Duration("timeout", 10*time.Second, "timeout of the call")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to connect to the sidecar:", err)
+		os.Exit(1)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	admin := client.NewGRPCAdminClient(conn)
+
+	switch flag.Arg(0) {
+	case "graph":
+		err = exportGraph(ctx, admin, flag.Args()[1:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func exportGraph(ctx context.Context, admin service.AdminService, args []string) error {
+	fs := flag.NewFlagSet("graph", flag.ExitOnError)
+	format := fs.String("format", "dot", "format of the graph: dot, mermaid or json")
+	fs.Parse(args)
+
+	res, err := admin.ExportGraph(ctx, &service.ExportGraphRequest{Format: *format})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(strings.TrimRight(res.Graph, "\n"))
+	return nil
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: cbadmin [-addr host:port] [-timeout duration] graph [-format dot|mermaid|json]")
+	flag.PrintDefaults()
+}
@@ -0,0 +1,310 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/daffarg/distributed-cascading-cb/circuitbreaker"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Breaker holds the circuit breaker settings of an endpoint.
+// Zero values are unset and fall back to BreakerDefaults, then to the built-in defaults.
+type Breaker struct {
+	Endpoint               string          `yaml:"endpoint" json:"endpoint"`
+	Method                 string          `yaml:"method" json:"method"`
+	Timeout                time.Duration   `yaml:"timeout" json:"timeout"`
+	MaxRequests            uint32          `yaml:"maxRequests" json:"max_requests"`
+	Interval               time.Duration   `yaml:"interval" json:"interval"`
+	MaxConsecutiveFailures uint32          `yaml:"maxConsecutiveFailures" json:"max_consecutive_failures"`
+	SlidingWindow          *SlidingWindow  `yaml:"slidingWindow" json:"sliding_window"`
+	Classification         *Classification `yaml:"classification" json:"classification"`
+	Backoff                *Backoff        `yaml:"backoff" json:"backoff"`
+	Mode                   string          `yaml:"mode" json:"mode"`
+	ForcedOpenTimeout      time.Duration   `yaml:"forcedOpenTimeout" json:"forced_open_timeout"`
+}
+
+// Modes of a circuit breaker. BreakerModeMetricsOnly records and reports trips without rejecting requests,
+// BreakerModeDisabled allows every request and only records its outcome.
+const (
+	BreakerModeEnforce     = "enforce"
+	BreakerModeMetricsOnly = "metrics-only"
+	BreakerModeDisabled    = "disabled"
+)
+
+// SlidingWindow selects the failure and slow call rate trip policy instead of consecutive failures.
+type SlidingWindow struct {
+	Type                 string  `yaml:"type" json:"type"`
+	Size                 uint32  `yaml:"size" json:"size"`
+	FailureRateThreshold float64 `yaml:"failureRateThreshold" json:"failure_rate_threshold"`
+	MinimumNumberOfCalls uint32  `yaml:"minimumNumberOfCalls" json:"minimum_number_of_calls"`
+
+	SlowCallDurationThreshold time.Duration `yaml:"slowCallDurationThreshold" json:"slow_call_duration_threshold"`
+	SlowCallRateThreshold     float64       `yaml:"slowCallRateThreshold" json:"slow_call_rate_threshold"`
+}
+
+// Backoff grows the open state timeout on each consecutive reopen, see circuitbreaker.BackoffPolicy.
+type Backoff struct {
+	Base       time.Duration `yaml:"base" json:"base"`
+	Multiplier float64       `yaml:"multiplier" json:"multiplier"`
+	Cap        time.Duration `yaml:"cap" json:"cap"`
+	Jitter     float64       `yaml:"jitter" json:"jitter"`
+}
+
+// Classification decides which upstream responses and errors are counted as failures.
+// FailureStatusCodes accepts exact codes such as "429", classes such as "5xx" and ranges such as "500-504".
+// A response is also a failure when it has one of the FailureHeaders or its body matches FailureBodyPattern.
+// FailureErrors lists the types of transport errors counted as failures, see the ErrorType constants,
+// every error is a failure when it is empty.
+// A response with a Retry-After header and one of the RetryAfterStatusCodes opens the circuit breaker
+// right away until the upstream is expected to recover, for at most MaxRetryAfter. When it is not set,
+// GetBreaker caps it at the backoff cap of the breaker, or at its timeout without one.
+type Classification struct {
+	FailureStatusCodes    []string      `yaml:"failureStatusCodes" json:"failure_status_codes"`
+	FailureHeaders        []string      `yaml:"failureHeaders" json:"failure_headers"`
+	FailureBodyPattern    string        `yaml:"failureBodyPattern" json:"failure_body_pattern"`
+	FailureErrors         []string      `yaml:"failureErrors" json:"failure_errors"`
+	RetryAfterStatusCodes []string      `yaml:"retryAfterStatusCodes" json:"retry_after_status_codes"`
+	MaxRetryAfter         time.Duration `yaml:"maxRetryAfter" json:"max_retry_after"`
+
+	// bodyPattern is compiled from FailureBodyPattern when the config is read
+	bodyPattern *regexp.Regexp
+}
+
+// Types of the transport errors of a request.
+const (
+	ErrorTypeTimeout    = "timeout"
+	ErrorTypeConnection = "connection"
+	ErrorTypeCanceled   = "canceled"
+	ErrorTypeOther      = "other"
+)
+
+var defaultBreaker = Breaker{
+	Timeout:                60 * time.Second,
+	MaxConsecutiveFailures: 5,
+	Mode:                   BreakerModeEnforce,
+	ForcedOpenTimeout:      24 * time.Hour,
+	Classification: &Classification{
+		FailureStatusCodes: []string{"5xx"},
+	},
+}
+
+// GetBreaker returns the circuit breaker settings of the endpoint with the defaults filled in.
+func (c *Config) GetBreaker(endpointName string) Breaker {
+	breaker := c.Breakers[endpointName]
+	breaker.fillFrom(c.BreakerDefaults)
+	breaker.fillFrom(defaultBreaker)
+
+	// the classification may be shared with other breakers, so the default cap is set on a copy
+	if breaker.Classification != nil && breaker.Classification.MaxRetryAfter <= 0 {
+		classification := *breaker.Classification
+		classification.MaxRetryAfter = breaker.Timeout
+		if breaker.Backoff != nil && breaker.Backoff.Cap > 0 {
+			classification.MaxRetryAfter = breaker.Backoff.Cap
+		}
+		breaker.Classification = &classification
+	}
+
+	return breaker
+}
+
+func (b *Breaker) fillFrom(defaults Breaker) {
+	if b.Timeout <= 0 {
+		b.Timeout = defaults.Timeout
+	}
+	if b.MaxRequests == 0 {
+		b.MaxRequests = defaults.MaxRequests
+	}
+	if b.Interval <= 0 {
+		b.Interval = defaults.Interval
+	}
+	if b.MaxConsecutiveFailures == 0 {
+		b.MaxConsecutiveFailures = defaults.MaxConsecutiveFailures
+	}
+	if b.SlidingWindow == nil {
+		b.SlidingWindow = defaults.SlidingWindow
+	}
+	if b.Classification == nil {
+		b.Classification = defaults.Classification
+	}
+	if b.Backoff == nil {
+		b.Backoff = defaults.Backoff
+	}
+	if b.Mode == "" {
+		b.Mode = defaults.Mode
+	}
+	if b.ForcedOpenTimeout <= 0 {
+		b.ForcedOpenTimeout = defaults.ForcedOpenTimeout
+	}
+}
+
+func (b *Breaker) validate() error {
+	switch b.Mode {
+	case "", BreakerModeEnforce, BreakerModeMetricsOnly, BreakerModeDisabled:
+	default:
+		return fmt.Errorf("unknown circuit breaker mode %q", b.Mode)
+	}
+
+	if b.SlidingWindow != nil {
+		_, err := circuitbreaker.ParseSlidingWindowType(b.SlidingWindow.Type)
+		if err != nil {
+			return err
+		}
+	}
+
+	if b.Classification != nil {
+		err := b.Classification.validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Classification) validate() error {
+	for _, patterns := range [][]string{c.FailureStatusCodes, c.RetryAfterStatusCodes} {
+		for _, pattern := range patterns {
+			_, _, err := parseStatusCodePattern(pattern)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, errorType := range c.FailureErrors {
+		switch errorType {
+		case ErrorTypeTimeout, ErrorTypeConnection, ErrorTypeCanceled, ErrorTypeOther:
+		default:
+			return fmt.Errorf("unknown error type %q", errorType)
+		}
+	}
+
+	if c.FailureBodyPattern != "" {
+		bodyPattern, err := regexp.Compile(c.FailureBodyPattern)
+		if err != nil {
+			return err
+		}
+		c.bodyPattern = bodyPattern
+	}
+
+	return nil
+}
+
+// IsFailureStatusCode reports whether a response with the given status code is counted as a failure.
+func (c *Classification) IsFailureStatusCode(statusCode int) bool {
+	return matchStatusCode(c.FailureStatusCodes, statusCode)
+}
+
+// IsFailureResponse reports whether the response is counted as a failure by its status code, headers or body.
+func (c *Classification) IsFailureResponse(statusCode int, header http.Header, body []byte) bool {
+	if c.IsFailureStatusCode(statusCode) || c.RetryAfter(statusCode, header, time.Now()) > 0 {
+		return true
+	}
+
+	for _, name := range c.FailureHeaders {
+		if _, ok := header[http.CanonicalHeaderKey(name)]; ok {
+			return true
+		}
+	}
+
+	return c.bodyPattern != nil && c.bodyPattern.Match(body)
+}
+
+// IsFailureError reports whether the transport error of a request is counted as a failure.
+func (c *Classification) IsFailureError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if len(c.FailureErrors) == 0 {
+		return true
+	}
+
+	errorType := GetErrorType(err)
+	for _, failureError := range c.FailureErrors {
+		if failureError == errorType {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryAfter returns how long the upstream asks to wait before retrying a response with one of the
+// RetryAfterStatusCodes, or 0 when the response does not open the circuit breaker.
+// The Retry-After header is either a number of seconds or an HTTP date.
+func (c *Classification) RetryAfter(statusCode int, header http.Header, now time.Time) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" || !matchStatusCode(c.RetryAfterStatusCodes, statusCode) {
+		return 0
+	}
+
+	var retryAfter time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		retryAfter = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		retryAfter = date.Sub(now)
+	}
+
+	if c.MaxRetryAfter > 0 && retryAfter > c.MaxRetryAfter {
+		retryAfter = c.MaxRetryAfter
+	}
+	return max(retryAfter, 0)
+}
+
+// GetErrorType returns the ErrorType constant of a transport error.
+func GetErrorType(err error) string {
+	var netErr net.Error
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ErrorTypeCanceled
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorTypeTimeout
+	case errors.As(err, &opErr), errors.As(err, &dnsErr), errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, syscall.ECONNRESET):
+		return ErrorTypeConnection
+	default:
+		return ErrorTypeOther
+	}
+}
+
+func matchStatusCode(patterns []string, statusCode int) bool {
+	for _, pattern := range patterns {
+		from, to, err := parseStatusCodePattern(pattern)
+		if err == nil && statusCode >= from && statusCode <= to {
+			return true
+		}
+	}
+	return false
+}
+
+// parseStatusCodePattern returns the inclusive range of status codes matched by an exact code, a class like "5xx"
+// or a range like "500-504".
+func parseStatusCodePattern(pattern string) (int, int, error) {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	if len(pattern) == 3 && strings.HasSuffix(pattern, "xx") && pattern[0] >= '1' && pattern[0] <= '5' {
+		class := int(pattern[0]-'0') * 100
+		return class, class + 99, nil
+	}
+
+	if from, to, ok := strings.Cut(pattern, "-"); ok {
+		fromCode, errFrom := strconv.Atoi(strings.TrimSpace(from))
+		toCode, errTo := strconv.Atoi(strings.TrimSpace(to))
+		if errFrom != nil || errTo != nil || fromCode < 100 || toCode > 599 || fromCode > toCode {
+			return 0, 0, fmt.Errorf("invalid status code pattern: %q", pattern)
+		}
+		return fromCode, toCode, nil
+	}
+
+	code, err := strconv.Atoi(pattern)
+	if err != nil || code < 100 || code > 599 {
+		return 0, 0, fmt.Errorf("invalid status code pattern: %q", pattern)
+	}
+	return code, code, nil
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClassification(t *testing.T) {
+	classification := &Classification{
+		FailureStatusCodes:    []string{"500-504", "429"},
+		FailureHeaders:        []string{"x-upstream-error"},
+		FailureBodyPattern:    `"status":\s*"error"`,
+		FailureErrors:         []string{ErrorTypeTimeout, ErrorTypeConnection},
+		RetryAfterStatusCodes: []string{"503"},
+		MaxRetryAfter:         time.Minute,
+	}
+	if err := classification.validate(); err != nil {
+		t.Fatalf("validate() error = %v", err)
+	}
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		statusCode     int
+		header         http.Header
+		body           string
+		wantFailure    bool
+		wantRetryAfter time.Duration
+	}{
+		{name: "Success", statusCode: 200, wantFailure: false},
+		{name: "Status_code_range", statusCode: 502, wantFailure: true},
+		{name: "Status_code_outside_range", statusCode: 505, wantFailure: false},
+		{name: "Specific_status_code", statusCode: 429, wantFailure: true},
+		{name: "Header_presence", statusCode: 200, header: http.Header{"X-Upstream-Error": {"1"}}, wantFailure: true},
+		{name: "Body_pattern", statusCode: 200, body: `{"status": "error"}`, wantFailure: true},
+		{name: "Retry_after_seconds", statusCode: 503, header: http.Header{"Retry-After": {"30"}}, wantFailure: true, wantRetryAfter: 30 * time.Second},
+		{name: "Retry_after_date", statusCode: 503, header: http.Header{"Retry-After": {now.Add(10 * time.Second).Format(http.TimeFormat)}}, wantFailure: true, wantRetryAfter: 10 * time.Second},
+		{name: "Retry_after_capped", statusCode: 503, header: http.Header{"Retry-After": {"3600"}}, wantFailure: true, wantRetryAfter: time.Minute},
+		{name: "Retry_after_other_status_code", statusCode: 429, header: http.Header{"Retry-After": {"30"}}, wantFailure: true, wantRetryAfter: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classification.IsFailureResponse(tt.statusCode, tt.header, []byte(tt.body)); got != tt.wantFailure {
+				t.Errorf("IsFailureResponse() = %v, want %v", got, tt.wantFailure)
+			}
+			if got := classification.RetryAfter(tt.statusCode, tt.header, now); got != tt.wantRetryAfter {
+				t.Errorf("RetryAfter() = %v, want %v", got, tt.wantRetryAfter)
+			}
+		})
+	}
+
+	if !classification.IsFailureError(context.DeadlineExceeded) {
+		t.Errorf("IsFailureError(%v) = false, want true", context.DeadlineExceeded)
+	}
+	if classification.IsFailureError(context.Canceled) {
+		t.Errorf("IsFailureError(%v) = true, want false", context.Canceled)
+	}
+	if classification.IsFailureError(errors.New("failed")) {
+		t.Errorf("IsFailureError() of an other error = true, want false")
+	}
+}
+
+func TestConfig_GetBreaker_maxRetryAfter(t *testing.T) {
+	defaults := &Classification{RetryAfterStatusCodes: []string{"503"}}
+	c := &Config{
+		BreakerDefaults: Breaker{Timeout: 30 * time.Second, Classification: defaults},
+		Breakers: map[string]Breaker{
+			"GET:localhost:8081/backoff": {Backoff: &Backoff{Base: 30 * time.Second, Multiplier: 2, Cap: 10 * time.Minute}},
+			"GET:localhost:8081/capped":  {Classification: &Classification{RetryAfterStatusCodes: []string{"503"}, MaxRetryAfter: time.Hour}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		endpoint string
+		want     time.Duration
+	}{
+		{name: "Timeout", endpoint: "GET:localhost:8081/hello", want: 30 * time.Second},
+		{name: "Backoff_cap", endpoint: "GET:localhost:8081/backoff", want: 10 * time.Minute},
+		{name: "Max_retry_after", endpoint: "GET:localhost:8081/capped", want: time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			classification := c.GetBreaker(tt.endpoint).Classification
+			if got := classification.RetryAfter(503, http.Header{"Retry-After": {"86400"}}, time.Now()); got != tt.want {
+				t.Errorf("RetryAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if defaults.MaxRetryAfter != 0 {
+		t.Errorf("GetBreaker() changed the shared classification, MaxRetryAfter = %v", defaults.MaxRetryAfter)
+	}
+}
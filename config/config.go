@@ -5,16 +5,21 @@ import (
 	"gopkg.in/yaml.v3"
 	"os"
 	"strings"
+	"time"
 )
 
 type Config struct {
 	AlternativeEndpoints map[string]AlternativeEndpoint `yaml:"alternativeEndpoints" json:"alternative_endpoints"`
 	Exceptions           map[string]Endpoint            `yaml:"exceptions" json:"exceptions"`
+	Breakers             map[string]Breaker             `yaml:"breakers" json:"breakers"`
+	BreakerDefaults      Breaker                        `yaml:"breakerDefaults" json:"breaker_defaults"`
 }
 
 type config struct {
 	AlternativeEndpoints []AlternativeEndpoint `yaml:"alternativeEndpoints" json:"alternative_endpoints"`
 	Exceptions           []Endpoint            `yaml:"exceptions" json:"exceptions"`
+	Breakers             []Breaker             `yaml:"breakers" json:"breakers"`
+	BreakerDefaults      *Breaker              `yaml:"breakerDefaults" json:"breaker_defaults"`
 }
 
 type AlternativeEndpoint struct {
@@ -32,6 +37,11 @@ func NewConfig() *Config {
 	return &Config{
 		AlternativeEndpoints: make(map[string]AlternativeEndpoint),
 		Exceptions:           make(map[string]Endpoint),
+		Breakers:             make(map[string]Breaker),
+		BreakerDefaults: Breaker{
+			Timeout:                time.Duration(util.GetIntEnv("CB_TIMEOUT", 60)) * time.Second,
+			MaxConsecutiveFailures: uint32(util.GetIntEnv("CB_MAX_CONSECUTIVE_FAILURES", 5)),
+		},
 	}
 }
 
@@ -80,5 +90,31 @@ func (c *Config) Read(configPath string) error {
 		c.Exceptions[key] = tmpConfig.Exceptions[i]
 	}
 
+	for i := range tmpConfig.Breakers {
+		tmpConfig.Breakers[i].Method = strings.ToUpper(tmpConfig.Breakers[i].Method)
+		parsedUrl, err := util.GetGeneralURLFormat(strings.ToLower(tmpConfig.Breakers[i].Endpoint))
+		if err != nil {
+			return err
+		}
+		key := util.FormEndpointName(parsedUrl, tmpConfig.Breakers[i].Method)
+
+		err = tmpConfig.Breakers[i].validate()
+		if err != nil {
+			return err
+		}
+
+		c.Breakers[key] = tmpConfig.Breakers[i]
+	}
+
+	if tmpConfig.BreakerDefaults != nil {
+		err = tmpConfig.BreakerDefaults.validate()
+		if err != nil {
+			return err
+		}
+
+		tmpConfig.BreakerDefaults.fillFrom(c.BreakerDefaults)
+		c.BreakerDefaults = *tmpConfig.BreakerDefaults
+	}
+
 	return err
 }
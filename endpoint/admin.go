@@ -0,0 +1,224 @@
+package endpoint
+
+import (
+	"context"
+
+	"github.com/daffarg/distributed-cascading-cb/service"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/log"
+)
+
+type AdminEndpoint struct {
+	ListBreakersEp   endpoint.Endpoint
+	GetBreakerEp     endpoint.Endpoint
+	ForceOpenEp      endpoint.Endpoint
+	ForceCloseEp     endpoint.Endpoint
+	ResetEp          endpoint.Endpoint
+	DisableEp        endpoint.Endpoint
+	SetMetricsOnlyEp endpoint.Endpoint
+	ListRequiringsEp endpoint.Endpoint
+	ExportGraphEp    endpoint.Endpoint
+}
+
+func NewAdminEndpoint(svc service.AdminService, log log.Logger) (AdminEndpoint, error) {
+	var listBreakersEp endpoint.Endpoint
+	{
+		listBreakersEp = makeListBreakersEndpoint(svc)
+	}
+
+	var getBreakerEp endpoint.Endpoint
+	{
+		getBreakerEp = makeGetBreakerEndpoint(svc)
+	}
+
+	var forceOpenEp endpoint.Endpoint
+	{
+		forceOpenEp = makeForceOpenEndpoint(svc)
+	}
+
+	var forceCloseEp endpoint.Endpoint
+	{
+		forceCloseEp = makeForceCloseEndpoint(svc)
+	}
+
+	var resetEp endpoint.Endpoint
+	{
+		resetEp = makeResetEndpoint(svc)
+	}
+
+	var disableEp endpoint.Endpoint
+	{
+		disableEp = makeDisableEndpoint(svc)
+	}
+
+	var setMetricsOnlyEp endpoint.Endpoint
+	{
+		setMetricsOnlyEp = makeSetMetricsOnlyEndpoint(svc)
+	}
+
+	var listRequiringsEp endpoint.Endpoint
+	{
+		listRequiringsEp = makeListRequiringsEndpoint(svc)
+	}
+
+	var exportGraphEp endpoint.Endpoint
+	{
+		exportGraphEp = makeExportGraphEndpoint(svc)
+	}
+
+	return AdminEndpoint{
+		ListBreakersEp:   listBreakersEp,
+		GetBreakerEp:     getBreakerEp,
+		ForceOpenEp:      forceOpenEp,
+		ForceCloseEp:     forceCloseEp,
+		ResetEp:          resetEp,
+		DisableEp:        disableEp,
+		SetMetricsOnlyEp: setMetricsOnlyEp,
+		ListRequiringsEp: listRequiringsEp,
+		ExportGraphEp:    exportGraphEp,
+	}, nil
+}
+
+func (a *AdminEndpoint) ListBreakers(ctx context.Context, req *service.ListBreakersRequest) (*service.ListBreakersResponse, error) {
+	resp, err := a.ListBreakersEp(ctx, req)
+	if err != nil {
+		return &service.ListBreakersResponse{}, err
+	}
+
+	return resp.(*service.ListBreakersResponse), nil
+}
+
+func (a *AdminEndpoint) GetBreaker(ctx context.Context, req *service.BreakerRequest) (*service.Breaker, error) {
+	resp, err := a.GetBreakerEp(ctx, req)
+	if err != nil {
+		return &service.Breaker{}, err
+	}
+
+	return resp.(*service.Breaker), nil
+}
+
+func (a *AdminEndpoint) ForceOpen(ctx context.Context, req *service.BreakerRequest) (*service.Breaker, error) {
+	resp, err := a.ForceOpenEp(ctx, req)
+	if err != nil {
+		return &service.Breaker{}, err
+	}
+
+	return resp.(*service.Breaker), nil
+}
+
+func (a *AdminEndpoint) ForceClose(ctx context.Context, req *service.BreakerRequest) (*service.Breaker, error) {
+	resp, err := a.ForceCloseEp(ctx, req)
+	if err != nil {
+		return &service.Breaker{}, err
+	}
+
+	return resp.(*service.Breaker), nil
+}
+
+func (a *AdminEndpoint) Reset(ctx context.Context, req *service.BreakerRequest) (*service.Breaker, error) {
+	resp, err := a.ResetEp(ctx, req)
+	if err != nil {
+		return &service.Breaker{}, err
+	}
+
+	return resp.(*service.Breaker), nil
+}
+
+func (a *AdminEndpoint) Disable(ctx context.Context, req *service.BreakerRequest) (*service.Breaker, error) {
+	resp, err := a.DisableEp(ctx, req)
+	if err != nil {
+		return &service.Breaker{}, err
+	}
+
+	return resp.(*service.Breaker), nil
+}
+
+func (a *AdminEndpoint) SetMetricsOnly(ctx context.Context, req *service.SetMetricsOnlyRequest) (*service.Breaker, error) {
+	resp, err := a.SetMetricsOnlyEp(ctx, req)
+	if err != nil {
+		return &service.Breaker{}, err
+	}
+
+	return resp.(*service.Breaker), nil
+}
+
+func (a *AdminEndpoint) ListRequirings(ctx context.Context, req *service.BreakerRequest) (*service.ListRequiringsResponse, error) {
+	resp, err := a.ListRequiringsEp(ctx, req)
+	if err != nil {
+		return &service.ListRequiringsResponse{}, err
+	}
+
+	return resp.(*service.ListRequiringsResponse), nil
+}
+
+func (a *AdminEndpoint) ExportGraph(ctx context.Context, req *service.ExportGraphRequest) (*service.ExportGraphResponse, error) {
+	resp, err := a.ExportGraphEp(ctx, req)
+	if err != nil {
+		return &service.ExportGraphResponse{}, err
+	}
+
+	return resp.(*service.ExportGraphResponse), nil
+}
+
+func makeListBreakersEndpoint(svc service.AdminService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*service.ListBreakersRequest)
+		return svc.ListBreakers(ctx, req)
+	}
+}
+
+func makeGetBreakerEndpoint(svc service.AdminService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*service.BreakerRequest)
+		return svc.GetBreaker(ctx, req)
+	}
+}
+
+func makeForceOpenEndpoint(svc service.AdminService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*service.BreakerRequest)
+		return svc.ForceOpen(ctx, req)
+	}
+}
+
+func makeForceCloseEndpoint(svc service.AdminService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*service.BreakerRequest)
+		return svc.ForceClose(ctx, req)
+	}
+}
+
+func makeResetEndpoint(svc service.AdminService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*service.BreakerRequest)
+		return svc.Reset(ctx, req)
+	}
+}
+
+func makeDisableEndpoint(svc service.AdminService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*service.BreakerRequest)
+		return svc.Disable(ctx, req)
+	}
+}
+
+func makeSetMetricsOnlyEndpoint(svc service.AdminService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*service.SetMetricsOnlyRequest)
+		return svc.SetMetricsOnly(ctx, req)
+	}
+}
+
+func makeListRequiringsEndpoint(svc service.AdminService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*service.BreakerRequest)
+		return svc.ListRequirings(ctx, req)
+	}
+}
+
+func makeExportGraphEndpoint(svc service.AdminService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*service.ExportGraphRequest)
+		return svc.ExportGraph(ctx, req)
+	}
+}
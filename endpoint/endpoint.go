@@ -3,17 +3,20 @@ package endpoint
 import (
 	"context"
 
+	"github.com/daffarg/distributed-cascading-cb/protobuf"
 	"github.com/daffarg/distributed-cascading-cb/service"
 	"github.com/go-kit/kit/endpoint"
 	"github.com/go-kit/log"
 )
 
 type CircuitBreakerEndpoint struct {
-	GeneralEp endpoint.Endpoint
-	GetEp     endpoint.Endpoint
-	PostEp    endpoint.Endpoint
-	PutEp     endpoint.Endpoint
-	DeleteEp  endpoint.Endpoint
+	GeneralEp       endpoint.Endpoint
+	GetEp           endpoint.Endpoint
+	PostEp          endpoint.Endpoint
+	PutEp           endpoint.Endpoint
+	DeleteEp        endpoint.Endpoint
+	GeneralStreamEp endpoint.Endpoint
+	WatchStatusEp   endpoint.Endpoint
 }
 
 func NewCircuitBreakerEndpoint(svc service.CircuitBreakerService, log log.Logger) (CircuitBreakerEndpoint, error) {
@@ -42,12 +45,24 @@ func NewCircuitBreakerEndpoint(svc service.CircuitBreakerService, log log.Logger
 		deleteEp = makeDeleteEndpoint(svc)
 	}
 
+	var generalStreamEp endpoint.Endpoint
+	{
+		generalStreamEp = makeGeneralStreamEndpoint(svc)
+	}
+
+	var watchStatusEp endpoint.Endpoint
+	{
+		watchStatusEp = makeWatchStatusEndpoint(svc)
+	}
+
 	return CircuitBreakerEndpoint{
-		GeneralEp: generalEp,
-		GetEp:     getEp,
-		PostEp:    postEp,
-		PutEp:     putEp,
-		DeleteEp:  deleteEp,
+		GeneralEp:       generalEp,
+		GetEp:           getEp,
+		PostEp:          postEp,
+		PutEp:           putEp,
+		DeleteEp:        deleteEp,
+		GeneralStreamEp: generalStreamEp,
+		WatchStatusEp:   watchStatusEp,
 	}, nil
 }
 
@@ -96,6 +111,24 @@ func (c *CircuitBreakerEndpoint) Delete(ctx context.Context, req *service.Delete
 	return resp.(*service.Response), nil
 }
 
+func (c *CircuitBreakerEndpoint) GeneralStream(ctx context.Context, req *service.StreamRequest) (*service.Response, error) {
+	resp, err := c.GeneralStreamEp(ctx, req)
+	if err != nil {
+		return &service.Response{}, err
+	}
+
+	return resp.(*service.Response), nil
+}
+
+func (c *CircuitBreakerEndpoint) WatchStatus(ctx context.Context, req *service.WatchStatusRequest) (<-chan *protobuf.Status, error) {
+	resp, err := c.WatchStatusEp(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(<-chan *protobuf.Status), nil
+}
+
 func makeGeneralEndpoint(svc service.CircuitBreakerService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(*service.GeneralRequest)
@@ -130,3 +163,17 @@ func makeDeleteEndpoint(svc service.CircuitBreakerService) endpoint.Endpoint {
 		return svc.Delete(ctx, req)
 	}
 }
+
+func makeGeneralStreamEndpoint(svc service.CircuitBreakerService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*service.StreamRequest)
+		return svc.GeneralStream(ctx, req)
+	}
+}
+
+func makeWatchStatusEndpoint(svc service.CircuitBreakerService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*service.WatchStatusRequest)
+		return svc.WatchStatus(ctx, req)
+	}
+}
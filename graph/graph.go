@@ -0,0 +1,192 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/daffarg/distributed-cascading-cb/circuitbreaker"
+	"github.com/daffarg/distributed-cascading-cb/repository"
+	"github.com/daffarg/distributed-cascading-cb/util"
+)
+
+const (
+	FormatDOT     = "dot"
+	FormatMermaid = "mermaid"
+	FormatJSON    = "json"
+)
+
+// Graph is the dependency graph of the endpoints built from the requirings sets. An edge goes from
+// the requiring endpoint to the endpoint it requires, so statuses cascade against the edges.
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+}
+
+// Node is an endpoint annotated with its stored status, which is empty when no status is stored
+type Node struct {
+	Name   string `json:"name"`
+	Status string `json:"status,omitempty"`
+}
+
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Build walks the requirings sets of the repository and reads the stored status of every endpoint found
+func Build(ctx context.Context, repo repository.Repository) (*Graph, error) {
+	keys, err := repo.Scan(ctx, fmt.Sprintf("%s*", util.RequiringsEndpointKeyPrefix), 15)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &Graph{Nodes: make([]Node, 0), Edges: make([]Edge, 0)}
+	names := make(map[string]struct{})
+	for _, key := range keys {
+		name := util.GetEndpointFromRequiringsKey(key)
+		names[name] = struct{}{}
+
+		requiringEndpoints, err := repo.GetMemberOfSet(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ep := range requiringEndpoints {
+			// the requirings of an endpoint include the endpoint itself
+			if ep == name {
+				continue
+			}
+			names[ep] = struct{}{}
+			g.Edges = append(g.Edges, Edge{From: ep, To: name})
+		}
+	}
+
+	for name := range names {
+		status, err := repo.Get(ctx, util.FormEndpointStatusKey(name))
+		if err != nil && !errors.Is(err, util.ErrKeyNotFound) {
+			return nil, err
+		}
+		g.Nodes = append(g.Nodes, Node{Name: name, Status: status})
+	}
+
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		return g.Nodes[i].Name < g.Nodes[j].Name
+	})
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+
+	return g, nil
+}
+
+// Render returns the graph in the given format
+func (g *Graph) Render(format string) (string, error) {
+	switch format {
+	case FormatDOT:
+		return g.DOT(), nil
+	case FormatMermaid:
+		return g.Mermaid(), nil
+	case FormatJSON:
+		content, err := json.MarshalIndent(g, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(content), nil
+	default:
+		return "", util.ErrUnknownGraphFormat
+	}
+}
+
+// DOT renders the graph in the Graphviz DOT language, open endpoints are red and half-open ones orange
+func (g *Graph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph dependencies {\n")
+	b.WriteString("\trankdir=LR;\n")
+
+	for _, node := range g.Nodes {
+		label := node.Name
+		if node.Status != "" {
+			label = fmt.Sprintf("%s\n%s", node.Name, node.Status)
+		}
+		fmt.Fprintf(&b, "\t%s [label=%s", dotQuote(node.Name), dotQuote(label))
+		switch statusClass(node.Status) {
+		case "open":
+			b.WriteString(", color=red")
+		case "halfopen":
+			b.WriteString(", color=orange")
+		}
+		b.WriteString("];\n")
+	}
+
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "\t%s -> %s;\n", dotQuote(edge.From), dotQuote(edge.To))
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid renders the graph as a Mermaid flowchart. Endpoint names are not valid Mermaid IDs,
+// so the nodes are numbered and labelled with their names.
+func (g *Graph) Mermaid() string {
+	ids := make(map[string]string, len(g.Nodes))
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	for i, node := range g.Nodes {
+		id := fmt.Sprintf("n%d", i)
+		ids[node.Name] = id
+
+		label := mermaidEscape(node.Name)
+		if node.Status != "" {
+			label = fmt.Sprintf("%s<br/>%s", label, mermaidEscape(node.Status))
+		}
+		fmt.Fprintf(&b, "\t%s[\"%s\"]", id, label)
+		if class := statusClass(node.Status); class != "" {
+			fmt.Fprintf(&b, ":::%s", class)
+		}
+		b.WriteString("\n")
+	}
+
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "\t%s --> %s\n", ids[edge.From], ids[edge.To])
+	}
+
+	b.WriteString("\tclassDef open stroke:#d00,stroke-width:2px\n")
+	b.WriteString("\tclassDef halfopen stroke:#f90,stroke-width:2px\n")
+	return b.String()
+}
+
+func statusClass(status string) string {
+	switch status {
+	case "":
+		return ""
+	case circuitbreaker.StateHalfOpen.String():
+		return "halfopen"
+	case circuitbreaker.StateClosed.String():
+		return ""
+	default:
+		// any other stored status blocks the requests to the endpoint
+		return "open"
+	}
+}
+
+var dotReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func dotQuote(s string) string {
+	return `"` + dotReplacer.Replace(s) + `"`
+}
+
+var mermaidReplacer = strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;")
+
+func mermaidEscape(s string) string {
+	return mermaidReplacer.Replace(s)
+}
@@ -0,0 +1,97 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/daffarg/distributed-cascading-cb/repository/memory"
+	"github.com/daffarg/distributed-cascading-cb/util"
+)
+
+func TestGraph_Render(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	// a requires b, and b requires c
+	_, _ = repo.AddMembersIntoSet(ctx, util.FormRequiringEndpointsKey("GET:b/api"), "GET:b/api", "GET:a/api")
+	_, _ = repo.AddMembersIntoSet(ctx, util.FormRequiringEndpointsKey("GET:c/api"), "GET:c/api", "GET:b/api")
+	_ = repo.Set(ctx, util.FormEndpointStatusKey("GET:c/api"), "open")
+	_ = repo.Set(ctx, util.FormEndpointStatusKey("GET:b/api"), "half-open")
+
+	g, err := Build(ctx, repo)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: FormatDOT,
+			want: "digraph dependencies {\n" +
+				"\trankdir=LR;\n" +
+				"\t\"GET:a/api\" [label=\"GET:a/api\"];\n" +
+				"\t\"GET:b/api\" [label=\"GET:b/api\\nhalf-open\", color=orange];\n" +
+				"\t\"GET:c/api\" [label=\"GET:c/api\\nopen\", color=red];\n" +
+				"\t\"GET:a/api\" -> \"GET:b/api\";\n" +
+				"\t\"GET:b/api\" -> \"GET:c/api\";\n" +
+				"}\n",
+		},
+		{
+			format: FormatMermaid,
+			want: "flowchart LR\n" +
+				"\tn0[\"GET:a/api\"]\n" +
+				"\tn1[\"GET:b/api<br/>half-open\"]:::halfopen\n" +
+				"\tn2[\"GET:c/api<br/>open\"]:::open\n" +
+				"\tn0 --> n1\n" +
+				"\tn1 --> n2\n" +
+				"\tclassDef open stroke:#d00,stroke-width:2px\n" +
+				"\tclassDef halfopen stroke:#f90,stroke-width:2px\n",
+		},
+		{
+			format: FormatJSON,
+			want: `{
+  "nodes": [
+    {
+      "name": "GET:a/api"
+    },
+    {
+      "name": "GET:b/api",
+      "status": "half-open"
+    },
+    {
+      "name": "GET:c/api",
+      "status": "open"
+    }
+  ],
+  "edges": [
+    {
+      "from": "GET:a/api",
+      "to": "GET:b/api"
+    },
+    {
+      "from": "GET:b/api",
+      "to": "GET:c/api"
+    }
+  ]
+}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got, err := g.Render(tt.format)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := g.Render("svg"); !errors.Is(err, util.ErrUnknownGraphFormat) {
+		t.Errorf("Render() error = %v, want %v", err, util.ErrUnknownGraphFormat)
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/daffarg/distributed-cascading-cb/config"
 	"github.com/daffarg/distributed-cascading-cb/tracer"
@@ -11,13 +12,21 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
 	"time"
 
+	"github.com/daffarg/distributed-cascading-cb/broker"
 	"github.com/daffarg/distributed-cascading-cb/broker/kafka"
+	"github.com/daffarg/distributed-cascading-cb/broker/memory"
+	natsbroker "github.com/daffarg/distributed-cascading-cb/broker/nats"
+	redisbroker "github.com/daffarg/distributed-cascading-cb/broker/redis"
 	"github.com/daffarg/distributed-cascading-cb/endpoint"
 	"github.com/daffarg/distributed-cascading-cb/protobuf"
+	"github.com/daffarg/distributed-cascading-cb/repository"
 	"github.com/daffarg/distributed-cascading-cb/repository/kvrocks"
+	memoryrepository "github.com/daffarg/distributed-cascading-cb/repository/memory"
 	"github.com/daffarg/distributed-cascading-cb/service"
 	"github.com/daffarg/distributed-cascading-cb/transport"
 	"github.com/daffarg/distributed-cascading-cb/util"
@@ -107,17 +116,23 @@ func main() {
 
 	otelTracer := otel.Tracer(util.GetEnv("SERVICE_NAME", "CB SERVICE"))
 
-	kvRocks, err := kvrocks.NewKVRocksRepository(
-		util.GetEnv("KVROCKS_HOST", "127.0.0.1"),
-		util.GetEnv("KVROCKS_PORT", "6666"),
-		util.GetEnv("KVROCKS_PASSWORD", ""),
-		util.GetIntEnv("KVROCKS_DB", 0),
-	)
-	if err != nil {
-		level.Error(log).Log(
-			util.LogError, err,
+	var repo repository.Repository
+	switch util.GetEnv("CB_REPOSITORY", "kvrocks") {
+	case "memory":
+		repo = memoryrepository.NewMemoryRepository()
+	default:
+		repo, err = kvrocks.NewKVRocksRepository(
+			util.GetEnv("KVROCKS_HOST", "127.0.0.1"),
+			util.GetEnv("KVROCKS_PORT", "6666"),
+			util.GetEnv("KVROCKS_PASSWORD", ""),
+			util.GetIntEnv("KVROCKS_DB", 0),
 		)
-		return
+		if err != nil {
+			level.Error(log).Log(
+				util.LogError, err,
+			)
+			return
+		}
 	}
 
 	cbConfig := config.NewConfig()
@@ -129,27 +144,75 @@ func main() {
 		return
 	}
 
-	kafkaBroker, err := kafka.NewKafkaBroker(
-		log,
-		util.GetEnv("KAFKA_CONFIG_PATH", "client.properties"),
-		cbConfig,
-	)
-	if err != nil {
-		level.Error(log).Log(
-			util.LogError, err,
+	var messageBroker broker.MessageBroker
+	switch util.GetEnv("CB_BROKER", "kafka") {
+	case "memory":
+		messageBroker = memory.NewMemoryBroker(log, memory.NewBus(), cbConfig)
+	case "nats":
+		messageBroker, err = natsbroker.NewNatsBroker(
+			log,
+			util.GetEnv("NATS_URL", "nats://127.0.0.1:4222"),
+			cbConfig,
 		)
-		return
+		if err != nil {
+			level.Error(log).Log(
+				util.LogError, err,
+			)
+			return
+		}
+	case "redis":
+		messageBroker, err = redisbroker.NewRedisBroker(
+			log,
+			util.GetEnv("KVROCKS_HOST", "127.0.0.1"),
+			util.GetEnv("KVROCKS_PORT", "6666"),
+			util.GetEnv("KVROCKS_PASSWORD", ""),
+			util.GetIntEnv("KVROCKS_DB", 0),
+			cbConfig,
+		)
+		if err != nil {
+			level.Error(log).Log(
+				util.LogError, err,
+			)
+			return
+		}
+	default:
+		messageBroker, err = kafka.NewKafkaBroker(
+			log,
+			util.GetEnv("KAFKA_CONFIG_PATH", "client.properties"),
+			cbConfig,
+		)
+		if err != nil {
+			level.Error(log).Log(
+				util.LogError, err,
+			)
+			return
+		}
 	}
 
+	defer func() {
+		if err := messageBroker.Close(); err != nil {
+			level.Error(log).Log(
+				util.LogError, err,
+			)
+		}
+	}()
+
+	streamTransport := http.DefaultTransport.(*http.Transport).Clone()
+	streamTransport.ResponseHeaderTimeout = 10 * time.Second
+
 	circuitBreakerSvc := service.NewCircuitBreakerService(
 		log,
 		validator.New(),
-		kvRocks,
-		kafkaBroker,
+		repo,
+		messageBroker,
 		&http.Client{
 			Timeout:   10 * time.Second,
 			Transport: otelhttp.NewTransport(http.DefaultTransport),
 		},
+		// the streamed bodies may take longer than any overall timeout, they are only bounded by the request context
+		&http.Client{
+			Transport: otelhttp.NewTransport(streamTransport),
+		},
 		otelTracer,
 		cbConfig,
 	)
@@ -162,11 +225,24 @@ func main() {
 		return
 	}
 
+	adminEndpoint, err := endpoint.NewAdminEndpoint(circuitBreakerSvc, sysLog)
+	if err != nil {
+		level.Error(log).Log(
+			util.LogError, err,
+		)
+		return
+	}
+
 	circuitBreakerServer := transport.NewCircuitBreakerServer(circuitBreakerEndpoint)
+	adminServer := transport.NewAdminServer(adminEndpoint)
 	address := fmt.Sprintf("%s:%s", util.GetEnv("SERVICE_IP", "127.0.0.1"), util.GetEnv("SERVICE_PORT", "5320"))
 
+	// the messages of the unary RPCs carry bodies up to the maximum buffered size besides their other fields
+	maxMsgSize := int(util.GetMaxBufferedBodySize()) + 1<<20
 	grpcServer := grpc.NewServer(
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.MaxRecvMsgSize(maxMsgSize),
+		grpc.MaxSendMsgSize(maxMsgSize),
 	)
 
 	lis, errListen := net.Listen("tcp", address)
@@ -178,12 +254,71 @@ func main() {
 	}
 
 	protobuf.RegisterCircuitBreakerServer(grpcServer, circuitBreakerServer)
+	protobuf.RegisterCircuitBreakerAdminServer(grpcServer, adminServer)
 	reflection.Register(grpcServer)
 
+	// the HTTP proxy and gateway are only served when their ports are set
+	var httpServers []*http.Server
+	if proxyPort := os.Getenv("CB_PROXY_PORT"); proxyPort != "" {
+		httpServers = append(httpServers, &http.Server{
+			Addr:    fmt.Sprintf("%s:%s", util.GetEnv("SERVICE_IP", "127.0.0.1"), proxyPort),
+			Handler: otelhttp.NewHandler(transport.NewHTTPProxyServer(circuitBreakerEndpoint), "http-proxy"),
+		})
+	}
+	if gatewayPort := os.Getenv("CB_GATEWAY_PORT"); gatewayPort != "" {
+		httpServers = append(httpServers, &http.Server{
+			Addr:    fmt.Sprintf("%s:%s", util.GetEnv("SERVICE_IP", "127.0.0.1"), gatewayPort),
+			Handler: otelhttp.NewHandler(transport.NewHTTPGatewayServer(circuitBreakerEndpoint, adminEndpoint), "http-gateway"),
+		})
+	}
+
+	for _, httpServer := range httpServers {
+		go func(httpServer *http.Server) {
+			level.Info(log).Log(util.LogMessage, fmt.Sprintf("Serving HTTP on %s", httpServer.Addr))
+			if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				level.Error(log).Log(
+					util.LogError, err,
+				)
+			}
+		}(httpServer)
+	}
+
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+		<-sig
+
+		for _, httpServer := range httpServers {
+			level.Info(log).Log(util.LogMessage, fmt.Sprintf("shutting down HTTP server on %s", httpServer.Addr))
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			if err := httpServer.Shutdown(ctx); err != nil {
+				level.Error(log).Log(
+					util.LogError, err,
+				)
+			}
+			cancel()
+		}
+
+		level.Info(log).Log(util.LogMessage, "shutting down gRPC server")
+		// the WatchStatus streams never end on their own, so the open streams are cut off after the deadline
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(10 * time.Second):
+			level.Warn(log).Log(util.LogMessage, "gRPC server did not stop gracefully, closing the open streams")
+			grpcServer.Stop()
+		}
+	}()
+
 	// Serve gRPC Server
 	level.Info(log).Log(util.LogMessage, fmt.Sprintf("Serving gRPC on %s", address))
-	level.Error(log).Log(
-		util.LogError,
-		grpcServer.Serve(lis),
-	)
+	if err := grpcServer.Serve(lis); err != nil {
+		level.Error(log).Log(
+			util.LogError, err,
+		)
+	}
 }
@@ -7,8 +7,8 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
-	time "time"
 
+	broker "github.com/daffarg/distributed-cascading-cb/broker"
 	protobuf "github.com/daffarg/distributed-cascading-cb/protobuf"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -36,6 +36,20 @@ func (m *MockMessageBroker) EXPECT() *MockMessageBrokerMockRecorder {
 	return m.recorder
 }
 
+// Close mocks base method.
+func (m *MockMessageBroker) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockMessageBrokerMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockMessageBroker)(nil).Close))
+}
+
 // Publish mocks base method.
 func (m *MockMessageBroker) Publish(ctx context.Context, topic string, message *protobuf.Status) error {
 	m.ctrl.T.Helper()
@@ -66,13 +80,13 @@ func (mr *MockMessageBrokerMockRecorder) Subscribe(ctx, topic interface{}) *gomo
 }
 
 // SubscribeAsync mocks base method.
-func (m *MockMessageBroker) SubscribeAsync(ctx context.Context, topic string, handler func(context.Context, string, string, time.Duration) error) {
+func (m *MockMessageBroker) SubscribeAsync(request broker.SubscribeAsyncRequest) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SubscribeAsync", ctx, topic, handler)
+	m.ctrl.Call(m, "SubscribeAsync", request)
 }
 
 // SubscribeAsync indicates an expected call of SubscribeAsync.
-func (mr *MockMessageBrokerMockRecorder) SubscribeAsync(ctx, topic, handler interface{}) *gomock.Call {
+func (mr *MockMessageBrokerMockRecorder) SubscribeAsync(request interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeAsync", reflect.TypeOf((*MockMessageBroker)(nil).SubscribeAsync), ctx, topic, handler)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeAsync", reflect.TypeOf((*MockMessageBroker)(nil).SubscribeAsync), request)
 }
@@ -55,6 +55,25 @@ func (mr *MockRepositoryMockRecorder) AddMembersIntoSet(ctx, key interface{}, me
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMembersIntoSet", reflect.TypeOf((*MockRepository)(nil).AddMembersIntoSet), varargs...)
 }
 
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, keys ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx interface{}, keys ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), varargs...)
+}
+
 // Get mocks base method.
 func (m *MockRepository) Get(ctx context.Context, key string) (string, error) {
 	m.ctrl.T.Helper()
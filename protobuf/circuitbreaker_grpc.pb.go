@@ -11,6 +11,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -27,6 +28,11 @@ type CircuitBreakerClient interface {
 	Post(ctx context.Context, in *PostRequest, opts ...grpc.CallOption) (*Response, error)
 	Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*Response, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Response, error)
+	// streaming variants of General for the bodies too large to be buffered
+	GeneralClientStream(ctx context.Context, opts ...grpc.CallOption) (CircuitBreaker_GeneralClientStreamClient, error)
+	GeneralServerStream(ctx context.Context, in *GeneralRequest, opts ...grpc.CallOption) (CircuitBreaker_GeneralServerStreamClient, error)
+	GeneralBidiStream(ctx context.Context, opts ...grpc.CallOption) (CircuitBreaker_GeneralBidiStreamClient, error)
+	WatchStatus(ctx context.Context, in *WatchStatusRequest, opts ...grpc.CallOption) (CircuitBreaker_WatchStatusClient, error)
 }
 
 type circuitBreakerClient struct {
@@ -82,6 +88,135 @@ func (c *circuitBreakerClient) Delete(ctx context.Context, in *DeleteRequest, op
 	return out, nil
 }
 
+func (c *circuitBreakerClient) GeneralClientStream(ctx context.Context, opts ...grpc.CallOption) (CircuitBreaker_GeneralClientStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CircuitBreaker_ServiceDesc.Streams[0], "/protobuf.CircuitBreaker/GeneralClientStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &circuitBreakerGeneralClientStreamClient{stream}
+	return x, nil
+}
+
+type CircuitBreaker_GeneralClientStreamClient interface {
+	Send(*StreamRequest) error
+	CloseAndRecv() (*Response, error)
+	grpc.ClientStream
+}
+
+type circuitBreakerGeneralClientStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *circuitBreakerGeneralClientStreamClient) Send(m *StreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *circuitBreakerGeneralClientStreamClient) CloseAndRecv() (*Response, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Response)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *circuitBreakerClient) GeneralServerStream(ctx context.Context, in *GeneralRequest, opts ...grpc.CallOption) (CircuitBreaker_GeneralServerStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CircuitBreaker_ServiceDesc.Streams[1], "/protobuf.CircuitBreaker/GeneralServerStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &circuitBreakerGeneralServerStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CircuitBreaker_GeneralServerStreamClient interface {
+	Recv() (*StreamResponse, error)
+	grpc.ClientStream
+}
+
+type circuitBreakerGeneralServerStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *circuitBreakerGeneralServerStreamClient) Recv() (*StreamResponse, error) {
+	m := new(StreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *circuitBreakerClient) GeneralBidiStream(ctx context.Context, opts ...grpc.CallOption) (CircuitBreaker_GeneralBidiStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CircuitBreaker_ServiceDesc.Streams[2], "/protobuf.CircuitBreaker/GeneralBidiStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &circuitBreakerGeneralBidiStreamClient{stream}
+	return x, nil
+}
+
+type CircuitBreaker_GeneralBidiStreamClient interface {
+	Send(*StreamRequest) error
+	Recv() (*StreamResponse, error)
+	grpc.ClientStream
+}
+
+type circuitBreakerGeneralBidiStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *circuitBreakerGeneralBidiStreamClient) Send(m *StreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *circuitBreakerGeneralBidiStreamClient) Recv() (*StreamResponse, error) {
+	m := new(StreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *circuitBreakerClient) WatchStatus(ctx context.Context, in *WatchStatusRequest, opts ...grpc.CallOption) (CircuitBreaker_WatchStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CircuitBreaker_ServiceDesc.Streams[3], "/protobuf.CircuitBreaker/WatchStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &circuitBreakerWatchStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CircuitBreaker_WatchStatusClient interface {
+	Recv() (*Status, error)
+	grpc.ClientStream
+}
+
+type circuitBreakerWatchStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *circuitBreakerWatchStatusClient) Recv() (*Status, error) {
+	m := new(Status)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // CircuitBreakerServer is the server API for CircuitBreaker service.
 // All implementations must embed UnimplementedCircuitBreakerServer
 // for forward compatibility
@@ -91,6 +226,11 @@ type CircuitBreakerServer interface {
 	Post(context.Context, *PostRequest) (*Response, error)
 	Put(context.Context, *PutRequest) (*Response, error)
 	Delete(context.Context, *DeleteRequest) (*Response, error)
+	// streaming variants of General for the bodies too large to be buffered
+	GeneralClientStream(CircuitBreaker_GeneralClientStreamServer) error
+	GeneralServerStream(*GeneralRequest, CircuitBreaker_GeneralServerStreamServer) error
+	GeneralBidiStream(CircuitBreaker_GeneralBidiStreamServer) error
+	WatchStatus(*WatchStatusRequest, CircuitBreaker_WatchStatusServer) error
 	mustEmbedUnimplementedCircuitBreakerServer()
 }
 
@@ -113,6 +253,18 @@ func (UnimplementedCircuitBreakerServer) Put(context.Context, *PutRequest) (*Res
 func (UnimplementedCircuitBreakerServer) Delete(context.Context, *DeleteRequest) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedCircuitBreakerServer) GeneralClientStream(CircuitBreaker_GeneralClientStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method GeneralClientStream not implemented")
+}
+func (UnimplementedCircuitBreakerServer) GeneralServerStream(*GeneralRequest, CircuitBreaker_GeneralServerStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method GeneralServerStream not implemented")
+}
+func (UnimplementedCircuitBreakerServer) GeneralBidiStream(CircuitBreaker_GeneralBidiStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method GeneralBidiStream not implemented")
+}
+func (UnimplementedCircuitBreakerServer) WatchStatus(*WatchStatusRequest, CircuitBreaker_WatchStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchStatus not implemented")
+}
 func (UnimplementedCircuitBreakerServer) mustEmbedUnimplementedCircuitBreakerServer() {}
 
 // UnsafeCircuitBreakerServer may be embedded to opt out of forward compatibility for this service.
@@ -216,6 +368,100 @@ func _CircuitBreaker_Delete_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CircuitBreaker_GeneralClientStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CircuitBreakerServer).GeneralClientStream(&circuitBreakerGeneralClientStreamServer{stream})
+}
+
+type CircuitBreaker_GeneralClientStreamServer interface {
+	SendAndClose(*Response) error
+	Recv() (*StreamRequest, error)
+	grpc.ServerStream
+}
+
+type circuitBreakerGeneralClientStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *circuitBreakerGeneralClientStreamServer) SendAndClose(m *Response) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *circuitBreakerGeneralClientStreamServer) Recv() (*StreamRequest, error) {
+	m := new(StreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _CircuitBreaker_GeneralServerStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GeneralRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CircuitBreakerServer).GeneralServerStream(m, &circuitBreakerGeneralServerStreamServer{stream})
+}
+
+type CircuitBreaker_GeneralServerStreamServer interface {
+	Send(*StreamResponse) error
+	grpc.ServerStream
+}
+
+type circuitBreakerGeneralServerStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *circuitBreakerGeneralServerStreamServer) Send(m *StreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CircuitBreaker_GeneralBidiStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CircuitBreakerServer).GeneralBidiStream(&circuitBreakerGeneralBidiStreamServer{stream})
+}
+
+type CircuitBreaker_GeneralBidiStreamServer interface {
+	Send(*StreamResponse) error
+	Recv() (*StreamRequest, error)
+	grpc.ServerStream
+}
+
+type circuitBreakerGeneralBidiStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *circuitBreakerGeneralBidiStreamServer) Send(m *StreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *circuitBreakerGeneralBidiStreamServer) Recv() (*StreamRequest, error) {
+	m := new(StreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _CircuitBreaker_WatchStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CircuitBreakerServer).WatchStatus(m, &circuitBreakerWatchStatusServer{stream})
+}
+
+type CircuitBreaker_WatchStatusServer interface {
+	Send(*Status) error
+	grpc.ServerStream
+}
+
+type circuitBreakerWatchStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *circuitBreakerWatchStatusServer) Send(m *Status) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // CircuitBreaker_ServiceDesc is the grpc.ServiceDesc for CircuitBreaker service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -244,6 +490,402 @@ var CircuitBreaker_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _CircuitBreaker_Delete_Handler,
 		},
 	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GeneralClientStream",
+			Handler:       _CircuitBreaker_GeneralClientStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GeneralServerStream",
+			Handler:       _CircuitBreaker_GeneralServerStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GeneralBidiStream",
+			Handler:       _CircuitBreaker_GeneralBidiStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchStatus",
+			Handler:       _CircuitBreaker_WatchStatus_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "circuitbreaker.proto",
+}
+
+// CircuitBreakerAdminClient is the client API for CircuitBreakerAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CircuitBreakerAdminClient interface {
+	ListBreakers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListBreakersResponse, error)
+	GetBreaker(ctx context.Context, in *BreakerRequest, opts ...grpc.CallOption) (*Breaker, error)
+	ForceOpen(ctx context.Context, in *BreakerRequest, opts ...grpc.CallOption) (*Breaker, error)
+	ForceClose(ctx context.Context, in *BreakerRequest, opts ...grpc.CallOption) (*Breaker, error)
+	Reset(ctx context.Context, in *BreakerRequest, opts ...grpc.CallOption) (*Breaker, error)
+	Disable(ctx context.Context, in *BreakerRequest, opts ...grpc.CallOption) (*Breaker, error)
+	SetMetricsOnly(ctx context.Context, in *SetMetricsOnlyRequest, opts ...grpc.CallOption) (*Breaker, error)
+	ListRequirings(ctx context.Context, in *BreakerRequest, opts ...grpc.CallOption) (*ListRequiringsResponse, error)
+	ExportGraph(ctx context.Context, in *ExportGraphRequest, opts ...grpc.CallOption) (*ExportGraphResponse, error)
+}
+
+type circuitBreakerAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCircuitBreakerAdminClient(cc grpc.ClientConnInterface) CircuitBreakerAdminClient {
+	return &circuitBreakerAdminClient{cc}
+}
+
+func (c *circuitBreakerAdminClient) ListBreakers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListBreakersResponse, error) {
+	out := new(ListBreakersResponse)
+	err := c.cc.Invoke(ctx, "/protobuf.CircuitBreakerAdmin/ListBreakers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *circuitBreakerAdminClient) GetBreaker(ctx context.Context, in *BreakerRequest, opts ...grpc.CallOption) (*Breaker, error) {
+	out := new(Breaker)
+	err := c.cc.Invoke(ctx, "/protobuf.CircuitBreakerAdmin/GetBreaker", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *circuitBreakerAdminClient) ForceOpen(ctx context.Context, in *BreakerRequest, opts ...grpc.CallOption) (*Breaker, error) {
+	out := new(Breaker)
+	err := c.cc.Invoke(ctx, "/protobuf.CircuitBreakerAdmin/ForceOpen", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *circuitBreakerAdminClient) ForceClose(ctx context.Context, in *BreakerRequest, opts ...grpc.CallOption) (*Breaker, error) {
+	out := new(Breaker)
+	err := c.cc.Invoke(ctx, "/protobuf.CircuitBreakerAdmin/ForceClose", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *circuitBreakerAdminClient) Reset(ctx context.Context, in *BreakerRequest, opts ...grpc.CallOption) (*Breaker, error) {
+	out := new(Breaker)
+	err := c.cc.Invoke(ctx, "/protobuf.CircuitBreakerAdmin/Reset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *circuitBreakerAdminClient) Disable(ctx context.Context, in *BreakerRequest, opts ...grpc.CallOption) (*Breaker, error) {
+	out := new(Breaker)
+	err := c.cc.Invoke(ctx, "/protobuf.CircuitBreakerAdmin/Disable", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *circuitBreakerAdminClient) SetMetricsOnly(ctx context.Context, in *SetMetricsOnlyRequest, opts ...grpc.CallOption) (*Breaker, error) {
+	out := new(Breaker)
+	err := c.cc.Invoke(ctx, "/protobuf.CircuitBreakerAdmin/SetMetricsOnly", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *circuitBreakerAdminClient) ListRequirings(ctx context.Context, in *BreakerRequest, opts ...grpc.CallOption) (*ListRequiringsResponse, error) {
+	out := new(ListRequiringsResponse)
+	err := c.cc.Invoke(ctx, "/protobuf.CircuitBreakerAdmin/ListRequirings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *circuitBreakerAdminClient) ExportGraph(ctx context.Context, in *ExportGraphRequest, opts ...grpc.CallOption) (*ExportGraphResponse, error) {
+	out := new(ExportGraphResponse)
+	err := c.cc.Invoke(ctx, "/protobuf.CircuitBreakerAdmin/ExportGraph", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CircuitBreakerAdminServer is the server API for CircuitBreakerAdmin service.
+// All implementations must embed UnimplementedCircuitBreakerAdminServer
+// for forward compatibility
+type CircuitBreakerAdminServer interface {
+	ListBreakers(context.Context, *emptypb.Empty) (*ListBreakersResponse, error)
+	GetBreaker(context.Context, *BreakerRequest) (*Breaker, error)
+	ForceOpen(context.Context, *BreakerRequest) (*Breaker, error)
+	ForceClose(context.Context, *BreakerRequest) (*Breaker, error)
+	Reset(context.Context, *BreakerRequest) (*Breaker, error)
+	Disable(context.Context, *BreakerRequest) (*Breaker, error)
+	SetMetricsOnly(context.Context, *SetMetricsOnlyRequest) (*Breaker, error)
+	ListRequirings(context.Context, *BreakerRequest) (*ListRequiringsResponse, error)
+	ExportGraph(context.Context, *ExportGraphRequest) (*ExportGraphResponse, error)
+	mustEmbedUnimplementedCircuitBreakerAdminServer()
+}
+
+// UnimplementedCircuitBreakerAdminServer must be embedded to have forward compatible implementations.
+type UnimplementedCircuitBreakerAdminServer struct {
+}
+
+func (UnimplementedCircuitBreakerAdminServer) ListBreakers(context.Context, *emptypb.Empty) (*ListBreakersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBreakers not implemented")
+}
+func (UnimplementedCircuitBreakerAdminServer) GetBreaker(context.Context, *BreakerRequest) (*Breaker, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBreaker not implemented")
+}
+func (UnimplementedCircuitBreakerAdminServer) ForceOpen(context.Context, *BreakerRequest) (*Breaker, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForceOpen not implemented")
+}
+func (UnimplementedCircuitBreakerAdminServer) ForceClose(context.Context, *BreakerRequest) (*Breaker, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForceClose not implemented")
+}
+func (UnimplementedCircuitBreakerAdminServer) Reset(context.Context, *BreakerRequest) (*Breaker, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reset not implemented")
+}
+func (UnimplementedCircuitBreakerAdminServer) Disable(context.Context, *BreakerRequest) (*Breaker, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Disable not implemented")
+}
+func (UnimplementedCircuitBreakerAdminServer) SetMetricsOnly(context.Context, *SetMetricsOnlyRequest) (*Breaker, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMetricsOnly not implemented")
+}
+func (UnimplementedCircuitBreakerAdminServer) ListRequirings(context.Context, *BreakerRequest) (*ListRequiringsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRequirings not implemented")
+}
+func (UnimplementedCircuitBreakerAdminServer) ExportGraph(context.Context, *ExportGraphRequest) (*ExportGraphResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportGraph not implemented")
+}
+func (UnimplementedCircuitBreakerAdminServer) mustEmbedUnimplementedCircuitBreakerAdminServer() {}
+
+// UnsafeCircuitBreakerAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CircuitBreakerAdminServer will
+// result in compilation errors.
+type UnsafeCircuitBreakerAdminServer interface {
+	mustEmbedUnimplementedCircuitBreakerAdminServer()
+}
+
+func RegisterCircuitBreakerAdminServer(s grpc.ServiceRegistrar, srv CircuitBreakerAdminServer) {
+	s.RegisterService(&CircuitBreakerAdmin_ServiceDesc, srv)
+}
+
+func _CircuitBreakerAdmin_ListBreakers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CircuitBreakerAdminServer).ListBreakers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.CircuitBreakerAdmin/ListBreakers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CircuitBreakerAdminServer).ListBreakers(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CircuitBreakerAdmin_GetBreaker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BreakerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CircuitBreakerAdminServer).GetBreaker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.CircuitBreakerAdmin/GetBreaker",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CircuitBreakerAdminServer).GetBreaker(ctx, req.(*BreakerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CircuitBreakerAdmin_ForceOpen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BreakerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CircuitBreakerAdminServer).ForceOpen(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.CircuitBreakerAdmin/ForceOpen",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CircuitBreakerAdminServer).ForceOpen(ctx, req.(*BreakerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CircuitBreakerAdmin_ForceClose_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BreakerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CircuitBreakerAdminServer).ForceClose(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.CircuitBreakerAdmin/ForceClose",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CircuitBreakerAdminServer).ForceClose(ctx, req.(*BreakerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CircuitBreakerAdmin_Reset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BreakerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CircuitBreakerAdminServer).Reset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.CircuitBreakerAdmin/Reset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CircuitBreakerAdminServer).Reset(ctx, req.(*BreakerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CircuitBreakerAdmin_Disable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BreakerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CircuitBreakerAdminServer).Disable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.CircuitBreakerAdmin/Disable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CircuitBreakerAdminServer).Disable(ctx, req.(*BreakerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CircuitBreakerAdmin_SetMetricsOnly_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMetricsOnlyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CircuitBreakerAdminServer).SetMetricsOnly(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.CircuitBreakerAdmin/SetMetricsOnly",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CircuitBreakerAdminServer).SetMetricsOnly(ctx, req.(*SetMetricsOnlyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CircuitBreakerAdmin_ListRequirings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BreakerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CircuitBreakerAdminServer).ListRequirings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.CircuitBreakerAdmin/ListRequirings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CircuitBreakerAdminServer).ListRequirings(ctx, req.(*BreakerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CircuitBreakerAdmin_ExportGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CircuitBreakerAdminServer).ExportGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protobuf.CircuitBreakerAdmin/ExportGraph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CircuitBreakerAdminServer).ExportGraph(ctx, req.(*ExportGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CircuitBreakerAdmin_ServiceDesc is the grpc.ServiceDesc for CircuitBreakerAdmin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CircuitBreakerAdmin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "protobuf.CircuitBreakerAdmin",
+	HandlerType: (*CircuitBreakerAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListBreakers",
+			Handler:    _CircuitBreakerAdmin_ListBreakers_Handler,
+		},
+		{
+			MethodName: "GetBreaker",
+			Handler:    _CircuitBreakerAdmin_GetBreaker_Handler,
+		},
+		{
+			MethodName: "ForceOpen",
+			Handler:    _CircuitBreakerAdmin_ForceOpen_Handler,
+		},
+		{
+			MethodName: "ForceClose",
+			Handler:    _CircuitBreakerAdmin_ForceClose_Handler,
+		},
+		{
+			MethodName: "Reset",
+			Handler:    _CircuitBreakerAdmin_Reset_Handler,
+		},
+		{
+			MethodName: "Disable",
+			Handler:    _CircuitBreakerAdmin_Disable_Handler,
+		},
+		{
+			MethodName: "SetMetricsOnly",
+			Handler:    _CircuitBreakerAdmin_SetMetricsOnly_Handler,
+		},
+		{
+			MethodName: "ListRequirings",
+			Handler:    _CircuitBreakerAdmin_ListRequirings_Handler,
+		},
+		{
+			MethodName: "ExportGraph",
+			Handler:    _CircuitBreakerAdmin_ExportGraph_Handler,
+		},
+	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "circuitbreaker.proto",
 }
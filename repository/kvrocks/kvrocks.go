@@ -96,6 +96,10 @@ func (k *kvRocks) IsKeyExist(ctx context.Context, key string) (bool, error) {
 	return isExist == 1, err
 }
 
+func (k *kvRocks) Delete(ctx context.Context, keys ...string) error {
+	return k.client.Del(ctx, keys...).Err()
+}
+
 func (k *kvRocks) Scan(ctx context.Context, pattern string, count int64) ([]string, error) {
 	var cursor uint64
 	keys := make([]string, 0)
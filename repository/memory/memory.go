@@ -0,0 +1,190 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/daffarg/distributed-cascading-cb/repository"
+	"github.com/daffarg/distributed-cascading-cb/util"
+)
+
+// entry holds either a string value or a set, like a Redis key
+type entry struct {
+	value  string
+	set    map[string]struct{}
+	expiry time.Time
+}
+
+func (e *entry) isExpired(now time.Time) bool {
+	return !e.expiry.IsZero() && !now.Before(e.expiry)
+}
+
+type memory struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+	now     func() time.Time
+}
+
+// NewMemoryRepository returns a Repository that keeps its keys in process memory, for local runs and tests
+func NewMemoryRepository() repository.Repository {
+	return &memory{
+		entries: make(map[string]*entry),
+		now:     time.Now,
+	}
+}
+
+func (m *memory) Set(ctx context.Context, key, value string) error {
+	return m.SetWithExp(ctx, key, value, 0)
+}
+
+// SetWithExp sets the key to the value, a non-positive exp keeps the key until it is overwritten or deleted
+func (m *memory) SetWithExp(_ context.Context, key, value string, exp time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e := &entry{value: value}
+	if exp > 0 {
+		e.expiry = m.now().Add(exp)
+	}
+	m.entries[key] = e
+
+	return nil
+}
+
+func (m *memory) Get(_ context.Context, key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.lookup(key)
+	if !ok {
+		return "", util.ErrKeyNotFound
+	}
+	if e.set != nil {
+		return "", util.ErrWrongType
+	}
+
+	return e.value, nil
+}
+
+func (m *memory) AddMembersIntoSet(_ context.Context, key string, members ...string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.lookup(key)
+	if !ok {
+		e = &entry{set: make(map[string]struct{})}
+		m.entries[key] = e
+	} else if e.set == nil {
+		return 0, util.ErrWrongType
+	}
+
+	var added int64
+	for _, member := range members {
+		if _, ok := e.set[member]; !ok {
+			e.set[member] = struct{}{}
+			added++
+		}
+	}
+
+	return added, nil
+}
+
+func (m *memory) IsMemberOfSet(ctx context.Context, key, value string) (bool, error) {
+	isMembers, err := m.IsMembersOfSet(ctx, key, value)
+	if err != nil {
+		return false, err
+	}
+
+	return isMembers[0], nil
+}
+
+func (m *memory) IsMembersOfSet(_ context.Context, key string, value ...string) ([]bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.lookup(key)
+	if ok && e.set == nil {
+		return nil, util.ErrWrongType
+	}
+
+	isMembers := make([]bool, len(value))
+	if ok {
+		for i, member := range value {
+			_, isMembers[i] = e.set[member]
+		}
+	}
+
+	return isMembers, nil
+}
+
+func (m *memory) GetMemberOfSet(_ context.Context, key string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.lookup(key)
+	if !ok {
+		return []string{}, nil
+	}
+	if e.set == nil {
+		return nil, util.ErrWrongType
+	}
+
+	members := make([]string, 0, len(e.set))
+	for member := range e.set {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+
+	return members, nil
+}
+
+func (m *memory) IsKeyExist(_ context.Context, key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, ok := m.lookup(key)
+	return ok, nil
+}
+
+// Scan returns every key matching the Redis glob pattern, count is only a hint for Redis and is ignored
+func (m *memory) Scan(_ context.Context, pattern string, _ int64) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := make([]string, 0)
+	for key := range m.entries {
+		if _, ok := m.lookup(key); ok && matchGlob(pattern, key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+func (m *memory) Delete(_ context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		delete(m.entries, key)
+	}
+
+	return nil
+}
+
+// lookup returns the entry of the key, removing it if it has expired
+func (m *memory) lookup(key string) (*entry, bool) {
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if e.isExpired(m.now()) {
+		delete(m.entries, key)
+		return nil, false
+	}
+
+	return e, true
+}
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"github.com/daffarg/distributed-cascading-cb/util"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_matchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		key     string
+		want    bool
+	}{
+		{pattern: "status:*", key: "status:GET:localhost:8081/hello", want: true},
+		{pattern: "status:*", key: "requirings:GET:localhost:8081/hello", want: false},
+		{pattern: "*hello", key: "status:GET:localhost:8081/hello", want: true},
+		{pattern: "h?llo", key: "hello", want: true},
+		{pattern: "h?llo", key: "hllo", want: false},
+		{pattern: "h[ae]llo", key: "hallo", want: true},
+		{pattern: "h[^e]llo", key: "hello", want: false},
+		{pattern: "h[a-b]llo", key: "hbllo", want: true},
+		{pattern: `h\*llo`, key: "h*llo", want: true},
+		{pattern: `h\*llo`, key: "hello", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+"_"+tt.key, func(t *testing.T) {
+			if got := matchGlob(tt.pattern, tt.key); got != tt.want {
+				t.Errorf("matchGlob(%q, %q) = %v, want %v", tt.pattern, tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_memory(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1000, 0)
+	m := &memory{
+		entries: make(map[string]*entry),
+		now:     func() time.Time { return now },
+	}
+
+	_ = m.SetWithExp(ctx, "status:a", "open", 10*time.Second)
+	_ = m.Set(ctx, "status:b", "open")
+	added, _ := m.AddMembersIntoSet(ctx, "requirings:a", "x", "y", "x")
+	if added != 2 {
+		t.Errorf("AddMembersIntoSet() = %d, want 2", added)
+	}
+
+	if _, err := m.Get(ctx, "requirings:a"); !errors.Is(err, util.ErrWrongType) {
+		t.Errorf("Get() on a set error = %v, want %v", err, util.ErrWrongType)
+	}
+	if got, _ := m.IsMembersOfSet(ctx, "requirings:a", "x", "z"); !reflect.DeepEqual(got, []bool{true, false}) {
+		t.Errorf("IsMembersOfSet() = %v, want [true false]", got)
+	}
+	if got, _ := m.Scan(ctx, "status:*", 10); !reflect.DeepEqual(got, []string{"status:a", "status:b"}) {
+		t.Errorf("Scan() = %v, want [status:a status:b]", got)
+	}
+
+	now = now.Add(10 * time.Second)
+	if _, err := m.Get(ctx, "status:a"); !errors.Is(err, util.ErrKeyNotFound) {
+		t.Errorf("Get() after expiry error = %v, want %v", err, util.ErrKeyNotFound)
+	}
+	if got, _ := m.Scan(ctx, "status:*", 10); !reflect.DeepEqual(got, []string{"status:b"}) {
+		t.Errorf("Scan() after expiry = %v, want [status:b]", got)
+	}
+
+	_ = m.Delete(ctx, "status:b", "requirings:a")
+	if got, _ := m.GetMemberOfSet(ctx, "requirings:a"); len(got) != 0 {
+		t.Errorf("GetMemberOfSet() after delete = %v, want none", got)
+	}
+}
@@ -15,4 +15,5 @@ type Repository interface {
 	GetMemberOfSet(ctx context.Context, key string) ([]string, error)
 	IsKeyExist(ctx context.Context, key string) (bool, error)
 	Scan(ctx context.Context, pattern string, count int64) ([]string, error)
+	Delete(ctx context.Context, keys ...string) error
 }
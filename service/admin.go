@@ -0,0 +1,368 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/daffarg/distributed-cascading-cb/circuitbreaker"
+	"github.com/daffarg/distributed-cascading-cb/graph"
+	"github.com/daffarg/distributed-cascading-cb/util"
+	"github.com/go-kit/log/level"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sort"
+	"strings"
+	"time"
+)
+
+type BreakerRequest struct {
+	Endpoint string `json:"endpoint" validate:"required"`
+	Method   string `json:"method" validate:"required"`
+}
+
+type SetMetricsOnlyRequest struct {
+	Endpoint string `json:"endpoint" validate:"required"`
+	Method   string `json:"method" validate:"required"`
+	Enabled  bool   `json:"enabled"`
+}
+
+type ListBreakersRequest struct{}
+
+type ListBreakersResponse struct {
+	Breakers []*Breaker `json:"breakers"`
+}
+
+type ListRequiringsResponse struct {
+	Name       string   `json:"name"`
+	Requirings []string `json:"requirings"`
+}
+
+type ExportGraphRequest struct {
+	Format string `json:"format" validate:"omitempty,oneof=dot mermaid json"`
+}
+
+type ExportGraphResponse struct {
+	Format string `json:"format"`
+	Graph  string `json:"graph"`
+}
+
+type Breaker struct {
+	Name                 string `json:"name"`
+	State                string `json:"state"`
+	StoredStatus         string `json:"stored_status"`
+	IsLocal              bool   `json:"is_local"`
+	Requests             uint32 `json:"requests"`
+	TotalSuccesses       uint32 `json:"total_successes"`
+	TotalFailures        uint32 `json:"total_failures"`
+	ConsecutiveSuccesses uint32 `json:"consecutive_successes"`
+	ConsecutiveFailures  uint32 `json:"consecutive_failures"`
+	WindowRequests       uint32 `json:"window_requests"`
+	WindowFailures       uint32 `json:"window_failures"`
+	WindowSlowCalls      uint32 `json:"window_slow_calls"`
+	OpenTimeout          uint32 `json:"open_timeout"`
+	MetricsOnly          bool   `json:"metrics_only"`
+}
+
+func (s *service) ListBreakers(ctx context.Context, _ *ListBreakersRequest) (*ListBreakersResponse, error) {
+	breakers := make(map[string]*Breaker)
+
+	s.breakersMu.Lock()
+	for name, cb := range s.breakers {
+		breakers[name] = newBreaker(name, cb)
+	}
+	s.breakersMu.Unlock()
+
+	keys, err := s.repository.Scan(ctx, fmt.Sprintf("%s*", util.StatusKeyPrefix), 15)
+	if err != nil {
+		level.Error(s.log).Log(
+			util.LogMessage, "failed to scan circuit breaker statuses from db",
+			util.LogError, err,
+		)
+		return &ListBreakersResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	for _, key := range keys {
+		storedStatus, err := s.repository.Get(ctx, key)
+		if err != nil {
+			// the status may have expired since the scan
+			continue
+		}
+
+		name := util.GetEndpointFromStatusKey(key)
+		breaker, ok := breakers[name]
+		if !ok {
+			breaker = &Breaker{Name: name}
+			breakers[name] = breaker
+		}
+		breaker.StoredStatus = storedStatus
+	}
+
+	res := &ListBreakersResponse{Breakers: make([]*Breaker, 0, len(breakers))}
+	for _, breaker := range breakers {
+		res.Breakers = append(res.Breakers, breaker)
+	}
+	sort.Slice(res.Breakers, func(i, j int) bool {
+		return res.Breakers[i].Name < res.Breakers[j].Name
+	})
+
+	return res, nil
+}
+
+func (s *service) GetBreaker(ctx context.Context, req *BreakerRequest) (*Breaker, error) {
+	name, err := s.validateBreakerRequest(req)
+	if err != nil {
+		return &Breaker{}, err
+	}
+
+	breaker, err := s.describeBreaker(ctx, name)
+	if err != nil {
+		return &Breaker{}, err
+	}
+	if !breaker.IsLocal && breaker.StoredStatus == "" {
+		return &Breaker{}, status.Error(codes.NotFound, util.ErrBreakerNotFound.Error())
+	}
+
+	return breaker, nil
+}
+
+// ForceOpen pins the circuit breaker open until it is force closed, and publishes the forced-open status to the cascade topics
+func (s *service) ForceOpen(ctx context.Context, req *BreakerRequest) (*Breaker, error) {
+	name, err := s.validateBreakerRequest(req)
+	if err != nil {
+		return &Breaker{}, err
+	}
+
+	level.Info(s.log).Log(
+		util.LogMessage, "forcing circuit breaker to open",
+		util.LogCircuitBreakerEndpoint, name,
+	)
+
+	s.getCircuitBreaker(name).ForceOpen()
+
+	return s.describeBreaker(ctx, name)
+}
+
+// ForceClose closes the circuit breaker, clears the stored statuses and publishes the closed status to the cascade topics
+func (s *service) ForceClose(ctx context.Context, req *BreakerRequest) (*Breaker, error) {
+	name, err := s.validateBreakerRequest(req)
+	if err != nil {
+		return &Breaker{}, err
+	}
+
+	level.Info(s.log).Log(
+		util.LogMessage, "forcing circuit breaker to close",
+		util.LogCircuitBreakerEndpoint, name,
+	)
+
+	cb := s.getCircuitBreaker(name)
+	from := cb.State()
+	cb.Reset()
+
+	// Reset publishes the recovery of an open breaker through OnStateChange,
+	// the statuses cascaded while the local breaker was closed are cleared here.
+	// The status of the endpoint itself is cleared whatever its root cause, since it was closed by hand.
+	cleared := make(chan struct{})
+	s.publishInOrder(func() {
+		if !isRecovery(from, circuitbreaker.StateClosed) || cb.MetricsOnly() {
+			s.publishRecovery(context.WithoutCancel(ctx), name, circuitbreaker.StateClosed)
+		}
+		err = s.repository.Delete(context.WithoutCancel(ctx), util.FormEndpointStatusKey(name), util.FormRootCauseKey(name))
+		close(cleared)
+	})
+	<-cleared
+	if err != nil {
+		level.Error(s.log).Log(
+			util.LogMessage, "failed to delete circuit breaker status from db",
+			util.LogError, err,
+			util.LogCircuitBreakerEndpoint, name,
+		)
+		return &Breaker{}, status.Error(codes.Internal, err.Error())
+	}
+
+	return s.describeBreaker(ctx, name)
+}
+
+// Reset clears the counts and backoff of a local circuit breaker. Resetting an open breaker closes it,
+// which publishes its recovery like any other state change.
+func (s *service) Reset(ctx context.Context, req *BreakerRequest) (*Breaker, error) {
+	name, err := s.validateBreakerRequest(req)
+	if err != nil {
+		return &Breaker{}, err
+	}
+
+	cb, ok := s.lookupCircuitBreaker(name)
+	if !ok {
+		return &Breaker{}, status.Error(codes.NotFound, util.ErrBreakerNotFound.Error())
+	}
+
+	cb.Reset()
+
+	return s.describeBreaker(ctx, name)
+}
+
+// Disable lets every request through the circuit breaker while still recording its outcome, until it is reset or force closed
+func (s *service) Disable(ctx context.Context, req *BreakerRequest) (*Breaker, error) {
+	name, err := s.validateBreakerRequest(req)
+	if err != nil {
+		return &Breaker{}, err
+	}
+
+	level.Info(s.log).Log(
+		util.LogMessage, "disabling circuit breaker",
+		util.LogCircuitBreakerEndpoint, name,
+	)
+
+	s.getCircuitBreaker(name).Disable()
+
+	return s.describeBreaker(ctx, name)
+}
+
+// SetMetricsOnly turns the metrics-only mode of the circuit breaker on or off
+func (s *service) SetMetricsOnly(ctx context.Context, req *SetMetricsOnlyRequest) (*Breaker, error) {
+	name, err := s.validateBreakerRequest(&BreakerRequest{
+		Endpoint: req.Endpoint,
+		Method:   req.Method,
+	})
+	if err != nil {
+		return &Breaker{}, err
+	}
+
+	level.Info(s.log).Log(
+		util.LogMessage, "setting circuit breaker metrics-only mode",
+		util.LogCircuitBreakerEndpoint, name,
+		util.LogMetricsOnly, req.Enabled,
+	)
+
+	s.getCircuitBreaker(name).SetMetricsOnly(req.Enabled)
+
+	return s.describeBreaker(ctx, name)
+}
+
+func (s *service) ListRequirings(ctx context.Context, req *BreakerRequest) (*ListRequiringsResponse, error) {
+	name, err := s.validateBreakerRequest(req)
+	if err != nil {
+		return &ListRequiringsResponse{}, err
+	}
+
+	requiringEndpoints, err := s.repository.GetMemberOfSet(ctx, util.FormRequiringEndpointsKey(name))
+	if err != nil {
+		level.Error(s.log).Log(
+			util.LogMessage, "failed to get requiring endpoints from db",
+			util.LogError, err,
+			util.LogCircuitBreakerEndpoint, name,
+		)
+		return &ListRequiringsResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	sort.Strings(requiringEndpoints)
+
+	return &ListRequiringsResponse{
+		Name:       name,
+		Requirings: requiringEndpoints,
+	}, nil
+}
+
+// ExportGraph renders the dependency graph of the requirings sets, annotated with the stored statuses, as DOT by default
+func (s *service) ExportGraph(ctx context.Context, req *ExportGraphRequest) (*ExportGraphResponse, error) {
+	if err := s.validator.Struct(req); err != nil {
+		level.Error(s.log).Log(
+			util.LogMessage, "failed precondition on request",
+			util.LogError, err,
+			util.LogRequest, req,
+		)
+		return &ExportGraphResponse{}, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	format := req.Format
+	if format == "" {
+		format = graph.FormatDOT
+	}
+
+	g, err := graph.Build(ctx, s.repository)
+	if err != nil {
+		level.Error(s.log).Log(
+			util.LogMessage, "failed to build the dependency graph from db",
+			util.LogError, err,
+		)
+		return &ExportGraphResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	content, err := g.Render(format)
+	if err != nil {
+		return &ExportGraphResponse{}, status.Error(codes.Internal, err.Error())
+	}
+
+	return &ExportGraphResponse{
+		Format: format,
+		Graph:  content,
+	}, nil
+}
+
+func (s *service) validateBreakerRequest(req *BreakerRequest) (string, error) {
+	if err := s.validator.Struct(req); err != nil {
+		level.Error(s.log).Log(
+			util.LogMessage, "failed precondition on request",
+			util.LogError, err,
+			util.LogRequest, req,
+		)
+		return "", status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	parsedUrl, err := util.GetGeneralURLFormat(strings.ToLower(req.Endpoint))
+	if err != nil {
+		level.Error(s.log).Log(
+			util.LogMessage, "failed parsing requested url",
+			util.LogError, err,
+			util.LogRequest, req,
+		)
+		return "", status.Error(codes.InvalidArgument, util.ErrFailedParsingURL.Error())
+	}
+
+	return util.FormEndpointName(parsedUrl, strings.ToUpper(req.Method)), nil
+}
+
+func (s *service) lookupCircuitBreaker(name string) (*circuitbreaker.CircuitBreaker, bool) {
+	s.breakersMu.Lock()
+	defer s.breakersMu.Unlock()
+
+	cb, ok := s.breakers[name]
+	return cb, ok
+}
+
+func (s *service) describeBreaker(ctx context.Context, name string) (*Breaker, error) {
+	breaker := &Breaker{Name: name}
+	if cb, ok := s.lookupCircuitBreaker(name); ok {
+		breaker = newBreaker(name, cb)
+	}
+
+	storedStatus, err := s.repository.Get(ctx, util.FormEndpointStatusKey(name))
+	if err != nil && !errors.Is(err, util.ErrKeyNotFound) {
+		level.Error(s.log).Log(
+			util.LogMessage, "failed to get cb status from db",
+			util.LogError, err,
+			util.LogCircuitBreakerEndpoint, name,
+		)
+		return &Breaker{}, status.Error(codes.Internal, err.Error())
+	}
+	breaker.StoredStatus = storedStatus
+
+	return breaker, nil
+}
+
+func newBreaker(name string, cb *circuitbreaker.CircuitBreaker) *Breaker {
+	counts := cb.Counts()
+	return &Breaker{
+		Name:                 name,
+		State:                cb.State().String(),
+		IsLocal:              true,
+		Requests:             counts.Requests,
+		TotalSuccesses:       counts.TotalSuccesses,
+		TotalFailures:        counts.TotalFailures,
+		ConsecutiveSuccesses: counts.ConsecutiveSuccesses,
+		ConsecutiveFailures:  counts.ConsecutiveFailures,
+		WindowRequests:       counts.WindowRequests,
+		WindowFailures:       counts.WindowFailures,
+		WindowSlowCalls:      counts.WindowSlowCalls,
+		OpenTimeout:          uint32(cb.OpenTimeout() / time.Second),
+		MetricsOnly:          cb.MetricsOnly(),
+	}
+}
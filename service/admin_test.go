@@ -0,0 +1,363 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/daffarg/distributed-cascading-cb/circuitbreaker"
+	"github.com/daffarg/distributed-cascading-cb/config"
+	"github.com/daffarg/distributed-cascading-cb/mock"
+	"github.com/daffarg/distributed-cascading-cb/protobuf"
+	"github.com/daffarg/distributed-cascading-cb/util"
+	logkit "github.com/go-kit/log"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusMatcher matches a published status by its endpoint and status
+type statusMatcher struct {
+	endpoint string
+	status   string
+}
+
+func (m statusMatcher) Matches(x interface{}) bool {
+	msg, ok := x.(*protobuf.Status)
+	return ok && msg.Endpoint == m.endpoint && msg.Status == m.status
+}
+
+func (m statusMatcher) String() string {
+	return "is a " + m.status + " status of " + m.endpoint
+}
+
+func Test_service_admin(t *testing.T) {
+	reqValidator := validator.New()
+
+	var svcLog logkit.Logger
+	{
+		svcLog = logkit.NewJSONLogger(os.Stdout)
+		svcLog = logkit.With(svcLog, util.LogTimestamp, logkit.TimestampFormat(time.Now, time.RFC3339), util.LogPath, logkit.DefaultCaller)
+	}
+
+	const (
+		endpoint  = "GET:localhost:8081/hello"
+		requiring = "GET:localhost:8080/hello"
+		remote    = "GET:localhost:8082/hello"
+	)
+	breakerReq := &BreakerRequest{Endpoint: "http://localhost:8081/hello", Method: "GET"}
+
+	type args struct {
+		call     func(ctx context.Context, s *service) (interface{}, error)
+		breakers func() map[string]*circuitbreaker.CircuitBreaker
+		mockFunc func(ctrl *gomock.Controller, mockRepository *mock.MockRepository, mockBroker *mock.MockMessageBroker)
+	}
+	tests := []struct {
+		name     string
+		args     args
+		want     interface{}
+		wantCode codes.Code
+	}{
+		{
+			name: "ListBreakers",
+			args: args{
+				call: func(ctx context.Context, s *service) (interface{}, error) {
+					return s.ListBreakers(ctx, &ListBreakersRequest{})
+				},
+				breakers: func() map[string]*circuitbreaker.CircuitBreaker {
+					return map[string]*circuitbreaker.CircuitBreaker{
+						endpoint: circuitbreaker.NewCircuitBreaker(circuitbreaker.Settings{Name: endpoint, Timeout: time.Minute}),
+					}
+				},
+				mockFunc: func(ctrl *gomock.Controller, mockRepository *mock.MockRepository, mockBroker *mock.MockMessageBroker) {
+					mockRepository.EXPECT().Scan(gomock.Any(), util.StatusKeyPrefix+"*", gomock.Any()).Return([]string{util.FormEndpointStatusKey(remote)}, nil)
+					mockRepository.EXPECT().Get(gomock.Any(), util.FormEndpointStatusKey(remote)).Return("open", nil)
+				},
+			},
+			want: &ListBreakersResponse{Breakers: []*Breaker{
+				{Name: endpoint, State: "closed", IsLocal: true, OpenTimeout: 60},
+				{Name: remote, StoredStatus: "open"},
+			}},
+		},
+		{
+			name: "ListBreakers_failed_scan",
+			args: args{
+				call: func(ctx context.Context, s *service) (interface{}, error) {
+					return s.ListBreakers(ctx, &ListBreakersRequest{})
+				},
+				mockFunc: func(ctrl *gomock.Controller, mockRepository *mock.MockRepository, mockBroker *mock.MockMessageBroker) {
+					mockRepository.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+				},
+			},
+			want:     &ListBreakersResponse{},
+			wantCode: codes.Internal,
+		},
+		{
+			name: "GetBreaker_stored_status",
+			args: args{
+				call: func(ctx context.Context, s *service) (interface{}, error) {
+					return s.GetBreaker(ctx, breakerReq)
+				},
+				mockFunc: func(ctrl *gomock.Controller, mockRepository *mock.MockRepository, mockBroker *mock.MockMessageBroker) {
+					mockRepository.EXPECT().Get(gomock.Any(), util.FormEndpointStatusKey(endpoint)).Return("open", nil)
+				},
+			},
+			want: &Breaker{Name: endpoint, StoredStatus: "open"},
+		},
+		{
+			name: "GetBreaker_not_found",
+			args: args{
+				call: func(ctx context.Context, s *service) (interface{}, error) {
+					return s.GetBreaker(ctx, breakerReq)
+				},
+				mockFunc: func(ctrl *gomock.Controller, mockRepository *mock.MockRepository, mockBroker *mock.MockMessageBroker) {
+					mockRepository.EXPECT().Get(gomock.Any(), util.FormEndpointStatusKey(endpoint)).Return("", util.ErrKeyNotFound)
+				},
+			},
+			want:     &Breaker{},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "GetBreaker_missing_method",
+			args: args{
+				call: func(ctx context.Context, s *service) (interface{}, error) {
+					return s.GetBreaker(ctx, &BreakerRequest{Endpoint: "http://localhost:8081/hello"})
+				},
+			},
+			want:     &Breaker{},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "ForceOpen",
+			args: args{
+				call: func(ctx context.Context, s *service) (interface{}, error) {
+					return s.ForceOpen(ctx, breakerReq)
+				},
+				mockFunc: func(ctrl *gomock.Controller, mockRepository *mock.MockRepository, mockBroker *mock.MockMessageBroker) {
+					mockRepository.EXPECT().GetMemberOfSet(gomock.Any(), util.FormRequiringEndpointsKey(endpoint)).Return([]string{endpoint, requiring}, nil)
+					mockBroker.EXPECT().Publish(gomock.Any(), util.EncodeTopic(endpoint), statusMatcher{endpoint: endpoint, status: "forced-open"}).Return(nil)
+					mockBroker.EXPECT().Publish(gomock.Any(), util.EncodeTopic(requiring), statusMatcher{endpoint: requiring, status: "forced-open"}).Return(nil)
+					mockRepository.EXPECT().SetWithExp(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(4)
+					mockRepository.EXPECT().Get(gomock.Any(), util.FormEndpointStatusKey(endpoint)).Return("forced-open", nil)
+				},
+			},
+			want: &Breaker{Name: endpoint, State: "forced-open", IsLocal: true, StoredStatus: "forced-open", OpenTimeout: 60},
+		},
+		{
+			name: "ForceClose_clears_statuses",
+			args: args{
+				call: func(ctx context.Context, s *service) (interface{}, error) {
+					return s.ForceClose(ctx, breakerReq)
+				},
+				mockFunc: func(ctrl *gomock.Controller, mockRepository *mock.MockRepository, mockBroker *mock.MockMessageBroker) {
+					mockRepository.EXPECT().GetMemberOfSet(gomock.Any(), util.FormRequiringEndpointsKey(endpoint)).Return([]string{endpoint, requiring}, nil)
+					mockRepository.EXPECT().Get(gomock.Any(), util.FormRootCauseKey(endpoint)).Return(endpoint, nil)
+					mockRepository.EXPECT().Get(gomock.Any(), util.FormRootCauseKey(requiring)).Return(endpoint, nil)
+					mockRepository.EXPECT().Delete(gomock.Any(), util.FormEndpointStatusKey(endpoint), util.FormRootCauseKey(endpoint)).Return(nil).Times(2)
+					mockRepository.EXPECT().Delete(gomock.Any(), util.FormEndpointStatusKey(requiring), util.FormRootCauseKey(requiring)).Return(nil)
+					mockBroker.EXPECT().Publish(gomock.Any(), util.EncodeTopic(endpoint), statusMatcher{endpoint: endpoint, status: "closed"}).Return(nil)
+					mockBroker.EXPECT().Publish(gomock.Any(), util.EncodeTopic(requiring), statusMatcher{endpoint: requiring, status: "closed"}).Return(nil)
+					mockRepository.EXPECT().Get(gomock.Any(), util.FormEndpointStatusKey(endpoint)).Return("", util.ErrKeyNotFound)
+				},
+			},
+			want: &Breaker{Name: endpoint, State: "closed", IsLocal: true, OpenTimeout: 60},
+		},
+		{
+			name: "ForceClose_keeps_status_of_another_root_cause",
+			args: args{
+				call: func(ctx context.Context, s *service) (interface{}, error) {
+					return s.ForceClose(ctx, breakerReq)
+				},
+				mockFunc: func(ctrl *gomock.Controller, mockRepository *mock.MockRepository, mockBroker *mock.MockMessageBroker) {
+					mockRepository.EXPECT().GetMemberOfSet(gomock.Any(), util.FormRequiringEndpointsKey(endpoint)).Return([]string{endpoint, requiring}, nil)
+					mockRepository.EXPECT().Get(gomock.Any(), util.FormRootCauseKey(endpoint)).Return(endpoint, nil)
+					mockRepository.EXPECT().Get(gomock.Any(), util.FormRootCauseKey(requiring)).Return(remote, nil)
+					mockRepository.EXPECT().Delete(gomock.Any(), util.FormEndpointStatusKey(endpoint), util.FormRootCauseKey(endpoint)).Return(nil).Times(2)
+					mockBroker.EXPECT().Publish(gomock.Any(), util.EncodeTopic(endpoint), statusMatcher{endpoint: endpoint, status: "closed"}).Return(nil)
+					mockRepository.EXPECT().Get(gomock.Any(), util.FormEndpointStatusKey(endpoint)).Return("", util.ErrKeyNotFound)
+				},
+			},
+			want: &Breaker{Name: endpoint, State: "closed", IsLocal: true, OpenTimeout: 60},
+		},
+		{
+			name: "ForceClose_clears_own_status_of_another_root_cause",
+			args: args{
+				call: func(ctx context.Context, s *service) (interface{}, error) {
+					return s.ForceClose(ctx, breakerReq)
+				},
+				mockFunc: func(ctrl *gomock.Controller, mockRepository *mock.MockRepository, mockBroker *mock.MockMessageBroker) {
+					mockRepository.EXPECT().GetMemberOfSet(gomock.Any(), util.FormRequiringEndpointsKey(endpoint)).Return([]string{endpoint, requiring}, nil)
+					mockRepository.EXPECT().Get(gomock.Any(), util.FormRootCauseKey(endpoint)).Return(remote, nil)
+					mockRepository.EXPECT().Get(gomock.Any(), util.FormRootCauseKey(requiring)).Return(remote, nil)
+					mockRepository.EXPECT().Delete(gomock.Any(), util.FormEndpointStatusKey(endpoint), util.FormRootCauseKey(endpoint)).Return(nil)
+					mockRepository.EXPECT().Get(gomock.Any(), util.FormEndpointStatusKey(endpoint)).Return("", util.ErrKeyNotFound)
+				},
+			},
+			want: &Breaker{Name: endpoint, State: "closed", IsLocal: true, OpenTimeout: 60},
+		},
+		{
+			name: "ForceClose_failed_delete",
+			args: args{
+				call: func(ctx context.Context, s *service) (interface{}, error) {
+					return s.ForceClose(ctx, breakerReq)
+				},
+				breakers: func() map[string]*circuitbreaker.CircuitBreaker {
+					cb := circuitbreaker.NewCircuitBreaker(circuitbreaker.Settings{Name: endpoint, Timeout: time.Minute})
+					cb.ForceOpen()
+					return map[string]*circuitbreaker.CircuitBreaker{endpoint: cb}
+				},
+				mockFunc: func(ctrl *gomock.Controller, mockRepository *mock.MockRepository, mockBroker *mock.MockMessageBroker) {
+					mockRepository.EXPECT().Delete(gomock.Any(), util.FormEndpointStatusKey(endpoint), util.FormRootCauseKey(endpoint)).Return(errors.New("connection refused"))
+				},
+			},
+			want:     &Breaker{},
+			wantCode: codes.Internal,
+		},
+		{
+			name: "Reset",
+			args: args{
+				call: func(ctx context.Context, s *service) (interface{}, error) {
+					return s.Reset(ctx, breakerReq)
+				},
+				breakers: func() map[string]*circuitbreaker.CircuitBreaker {
+					cb := circuitbreaker.NewCircuitBreaker(circuitbreaker.Settings{Name: endpoint, Timeout: time.Minute})
+					cb.Execute(func() (interface{}, error) {
+						return nil, errors.New("failed")
+					})
+					return map[string]*circuitbreaker.CircuitBreaker{endpoint: cb}
+				},
+				mockFunc: func(ctrl *gomock.Controller, mockRepository *mock.MockRepository, mockBroker *mock.MockMessageBroker) {
+					mockRepository.EXPECT().Get(gomock.Any(), util.FormEndpointStatusKey(endpoint)).Return("", util.ErrKeyNotFound)
+				},
+			},
+			want: &Breaker{Name: endpoint, State: "closed", IsLocal: true, OpenTimeout: 60},
+		},
+		{
+			name: "Reset_not_found",
+			args: args{
+				call: func(ctx context.Context, s *service) (interface{}, error) {
+					return s.Reset(ctx, breakerReq)
+				},
+			},
+			want:     &Breaker{},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "Disable",
+			args: args{
+				call: func(ctx context.Context, s *service) (interface{}, error) {
+					return s.Disable(ctx, breakerReq)
+				},
+				mockFunc: func(ctrl *gomock.Controller, mockRepository *mock.MockRepository, mockBroker *mock.MockMessageBroker) {
+					mockRepository.EXPECT().Get(gomock.Any(), util.FormEndpointStatusKey(endpoint)).Return("", util.ErrKeyNotFound)
+				},
+			},
+			want: &Breaker{Name: endpoint, State: "disabled", IsLocal: true, OpenTimeout: 60},
+		},
+		{
+			name: "SetMetricsOnly",
+			args: args{
+				call: func(ctx context.Context, s *service) (interface{}, error) {
+					return s.SetMetricsOnly(ctx, &SetMetricsOnlyRequest{Endpoint: "http://localhost:8081/hello", Method: "GET", Enabled: true})
+				},
+				mockFunc: func(ctrl *gomock.Controller, mockRepository *mock.MockRepository, mockBroker *mock.MockMessageBroker) {
+					mockRepository.EXPECT().Get(gomock.Any(), util.FormEndpointStatusKey(endpoint)).Return("", util.ErrKeyNotFound)
+				},
+			},
+			want: &Breaker{Name: endpoint, State: "closed", IsLocal: true, OpenTimeout: 60, MetricsOnly: true},
+		},
+		{
+			name: "ListRequirings",
+			args: args{
+				call: func(ctx context.Context, s *service) (interface{}, error) {
+					return s.ListRequirings(ctx, breakerReq)
+				},
+				mockFunc: func(ctrl *gomock.Controller, mockRepository *mock.MockRepository, mockBroker *mock.MockMessageBroker) {
+					mockRepository.EXPECT().GetMemberOfSet(gomock.Any(), util.FormRequiringEndpointsKey(endpoint)).Return([]string{requiring, endpoint}, nil)
+				},
+			},
+			want: &ListRequiringsResponse{Name: endpoint, Requirings: []string{requiring, endpoint}},
+		},
+		{
+			name: "ExportGraph",
+			args: args{
+				call: func(ctx context.Context, s *service) (interface{}, error) {
+					return s.ExportGraph(ctx, &ExportGraphRequest{Format: "mermaid"})
+				},
+				mockFunc: func(ctrl *gomock.Controller, mockRepository *mock.MockRepository, mockBroker *mock.MockMessageBroker) {
+					mockRepository.EXPECT().Scan(gomock.Any(), util.RequiringsEndpointKeyPrefix+"*", gomock.Any()).Return([]string{util.FormRequiringEndpointsKey(endpoint)}, nil)
+					mockRepository.EXPECT().GetMemberOfSet(gomock.Any(), util.FormRequiringEndpointsKey(endpoint)).Return([]string{endpoint, requiring}, nil)
+					mockRepository.EXPECT().Get(gomock.Any(), util.FormEndpointStatusKey(endpoint)).Return("open", nil)
+					mockRepository.EXPECT().Get(gomock.Any(), util.FormEndpointStatusKey(requiring)).Return("", util.ErrKeyNotFound)
+				},
+			},
+			want: &ExportGraphResponse{
+				Format: "mermaid",
+				Graph: "flowchart LR\n" +
+					"\tn0[\"GET:localhost:8080/hello\"]\n" +
+					"\tn1[\"GET:localhost:8081/hello<br/>open\"]:::open\n" +
+					"\tn0 --> n1\n" +
+					"\tclassDef open stroke:#d00,stroke-width:2px\n" +
+					"\tclassDef halfopen stroke:#f90,stroke-width:2px\n",
+			},
+		},
+		{
+			name: "ExportGraph_unknown_format",
+			args: args{
+				call: func(ctx context.Context, s *service) (interface{}, error) {
+					return s.ExportGraph(ctx, &ExportGraphRequest{Format: "svg"})
+				},
+			},
+			want:     &ExportGraphResponse{},
+			wantCode: codes.FailedPrecondition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepository := mock.NewMockRepository(ctrl)
+			mockBroker := mock.NewMockMessageBroker(ctrl)
+
+			breakers := make(map[string]*circuitbreaker.CircuitBreaker)
+			if tt.args.breakers != nil {
+				breakers = tt.args.breakers()
+			}
+
+			s := &service{
+				log:          svcLog,
+				validator:    reqValidator,
+				repository:   mockRepository,
+				broker:       mockBroker,
+				breakers:     breakers,
+				config:       config.NewConfig(),
+				subscribeMap: make(map[string]bool),
+			}
+			if tt.args.mockFunc != nil {
+				tt.args.mockFunc(ctrl, mockRepository, mockBroker)
+			}
+
+			got, err := tt.args.call(context.Background(), s)
+
+			// the publications of the state changes run after the call
+			published := make(chan struct{})
+			s.publishInOrder(func() {
+				close(published)
+			})
+			<-published
+
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("error = %v, want code %v", err, tt.wantCode)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
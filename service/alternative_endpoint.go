@@ -8,17 +8,19 @@ import (
 	"github.com/go-kit/log/level"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"net/http"
 	"net/url"
 )
 
 type executeAlternativeEndpointReq struct {
 	Request             *request
 	AlternativeEndpoint config.AlternativeEndpoint
-	Body                []byte
-	Header              map[string]string
+	Header              http.Header
 }
 
 func (s *service) executeAlternativeEndpoint(ctx context.Context, req *executeAlternativeEndpointReq) (*Response, error) {
+	// the last upstream response that failed is returned when no alternative endpoint succeeds
+	var lastFailedResponse *Response
 	for _, alt := range req.AlternativeEndpoint.Alternatives {
 		endpoint := util.FormEndpointName(alt.Endpoint, alt.Method)
 		_, err := s.repository.Get(ctx, util.FormEndpointStatusKey(endpoint))
@@ -43,9 +45,20 @@ func (s *service) executeAlternativeEndpoint(ctx context.Context, req *executeAl
 
 			parsedAltEndpoint.RawQuery = altQueryParams.Encode()
 
+			// a streamed body is gone once it was sent to an alternative endpoint
+			body, err := req.Request.body()
+			if err != nil {
+				level.Error(s.log).Log(
+					util.LogMessage, "cannot retry the streamed request on the next alternative endpoint",
+					util.LogError, err,
+					util.LogAlternativeEndpoint, endpoint,
+				)
+				break
+			}
+
 			// do request if error when getting cb status or cb status is not open
 			response, err := s.getCircuitBreaker(endpoint).Execute(func() (interface{}, error) {
-				return s.httpRequest(ctx, alt.Method, parsedAltEndpoint.String(), req.Body, req.Header)
+				return s.httpRequest(ctx, alt.Method, parsedAltEndpoint.String(), body, req.Header, req.Request.Stream)
 			})
 			if err != nil {
 				level.Error(s.log).Log(
@@ -53,12 +66,24 @@ func (s *service) executeAlternativeEndpoint(ctx context.Context, req *executeAl
 					util.LogError, err,
 					util.LogAlternativeEndpoint, endpoint,
 				)
+				if errors.Is(err, util.ErrBodyTooLarge) {
+					closeResponse(lastFailedResponse)
+					return &Response{}, err
+				}
+				if res, ok := failedResponse(response, err); ok {
+					closeResponse(lastFailedResponse)
+					lastFailedResponse = res
+				}
 			} else {
+				closeResponse(lastFailedResponse)
 				return response.(*Response), nil
 			}
 		}
 	}
 
 	// If all alternative endpoints are in open state or failed to execute the requests
+	if lastFailedResponse != nil {
+		return lastFailedResponse, &failureResponseError{statusCode: int(lastFailedResponse.StatusCode)}
+	}
 	return &Response{}, status.Error(codes.Internal, util.ErrFailedExecuteAltEndpoint.Error())
 }
@@ -4,25 +4,39 @@ import (
 	"context"
 	"errors"
 	"github.com/daffarg/distributed-cascading-cb/protobuf"
-	"time"
 
+	"github.com/daffarg/distributed-cascading-cb/broker"
 	"github.com/daffarg/distributed-cascading-cb/circuitbreaker"
+	"github.com/daffarg/distributed-cascading-cb/config"
 	"github.com/daffarg/distributed-cascading-cb/util"
 	"github.com/go-kit/log/level"
+	"time"
 )
 
+// publishQueueSize is the number of publications of the local transitions that may wait for the previous ones
+// before a transition blocks on them
+const publishQueueSize = 64
+
 func (s *service) getCircuitBreaker(name string) *circuitbreaker.CircuitBreaker {
+	s.breakersMu.Lock()
+	defer s.breakersMu.Unlock()
+
 	if cb, ok := s.breakers[name]; ok {
 		return cb
 	}
 
-	timeout := time.Duration(util.GetIntEnv("CB_TIMEOUT", 60)) * time.Second
+	var cb *circuitbreaker.CircuitBreaker
+	breakerConfig := s.config.GetBreaker(name)
 	st := circuitbreaker.Settings{
-		Name: name,
+		Name:        name,
+		MaxRequests: breakerConfig.MaxRequests,
+		Interval:    breakerConfig.Interval,
 		ReadyToTrip: func(counts circuitbreaker.Counts) bool {
-			return counts.ConsecutiveFailures >= uint32(util.GetIntEnv("CB_MAX_CONSECUTIVE_FAILURES", 5))
+			return counts.ConsecutiveFailures >= breakerConfig.MaxConsecutiveFailures
 		},
-		Timeout: timeout,
+		Timeout:      breakerConfig.Timeout,
+		IsSuccessful: isSuccessful(breakerConfig.Classification),
+		RetryAfter:   retryAfter,
 		OnStateChange: func(name string, from circuitbreaker.State, to circuitbreaker.State) {
 			level.Info(s.log).Log(
 				util.LogMessage, "circuit breaker state change",
@@ -31,161 +45,269 @@ func (s *service) getCircuitBreaker(name string) *circuitbreaker.CircuitBreaker
 				util.LogCircuitBreakerNewStatus, to,
 			)
 
-			if to == circuitbreaker.StateOpen {
-				isThereAlt := false
-				if alt, ok := s.config.AlternativeEndpoints[name]; ok {
-					for _, ep := range alt.Alternatives {
-						endpointName := util.FormEndpointName(ep.Endpoint, ep.Method)
-						_, err := s.repository.Get(context.Background(), util.FormEndpointStatusKey(endpointName))
-						if err != nil {
-							if errors.Is(err, util.ErrKeyNotFound) {
-								isThereAlt = true
-								break
-							} else {
-								level.Error(s.log).Log(
-									util.LogMessage, "failed to get endpoint status from db",
-									util.LogEndpoint, endpointName,
-									util.LogError, err,
-								)
-							}
-						}
-					}
-				}
+			timeout := cb.OpenTimeout()
+			if to == circuitbreaker.StateForcedOpen {
+				timeout = breakerConfig.ForcedOpenTimeout
+			}
+			s.notifyStateChange(name, to, timeout)
 
-				if !isThereAlt {
-					go func() {
-						requiringEndpoints, err := s.repository.GetMemberOfSet(context.Background(), util.FormRequiringEndpointsKey(name))
-						if err != nil {
-							level.Error(s.log).Log(
-								util.LogMessage, "failed to get requiring endpoints from db",
-								util.LogError, err,
-								util.LogCircuitBreakerEndpoint, name,
-								util.LogCircuitBreakerNewStatus, to.String(),
-							)
-
-							err := s.repository.SetWithExp(
-								context.Background(),
-								util.FormEndpointStatusKey(name),
-								to.String(),
-								time.Duration(util.GetIntEnv("CB_TIMEOUT", 60))*time.Second,
-							)
-							if err != nil {
-								level.Error(s.log).Log(
-									util.LogMessage, "failed to set circuit breaker status to db",
-									util.LogError, err,
-									util.LogCircuitBreakerEndpoint, name,
-									util.LogCircuitBreakerNewStatus, to.String(),
-								)
-							}
-						} else {
-							for _, ep := range requiringEndpoints {
-								encodedTopic := util.EncodeTopic(ep)
-								message := &protobuf.Status{
-									Endpoint:  ep,
-									Status:    to.String(),
-									Timeout:   uint32(util.GetIntEnv("CB_TIMEOUT", 60)),
-									Timestamp: time.Now().Format(time.RFC3339),
-								}
-								if err != nil {
-									level.Error(s.log).Log(
-										util.LogMessage, "failed to marshal circuit breaker status",
-										util.LogError, err,
-										util.LogCircuitBreakerEndpoint, ep,
-										util.LogCircuitBreakerNewStatus, to.String(),
-									)
-								}
-
-								err = s.broker.Publish(context.Background(), encodedTopic, message)
-								if err != nil {
-									level.Error(s.log).Log(
-										util.LogMessage, "failed to publish circuit breaker status",
-										util.LogError, err,
-										util.LogCircuitBreakerEndpoint, ep,
-										util.LogCircuitBreakerNewStatus, to.String(),
-									)
-								} else {
-									level.Info(s.log).Log(
-										util.LogMessage, "published circuit breaker status",
-										util.LogStatus, message,
-									)
-								}
-
-								err = s.repository.SetWithExp(
-									context.Background(),
-									util.FormEndpointStatusKey(ep),
-									to.String(),
-									time.Duration(util.GetIntEnv("CB_TIMEOUT", 60))*time.Second,
-								)
-								if err != nil {
-									level.Error(s.log).Log(
-										util.LogMessage, "failed to set circuit breaker status to db",
-										util.LogError, err,
-										util.LogCircuitBreakerEndpoint, ep,
-										util.LogCircuitBreakerNewStatus, to.String(),
-									)
-								}
-							}
-						}
-					}()
-				} else {
-					level.Info(s.log).Log(
-						util.LogMessage, "there are still alternative endpoints, only publishing the endpoint not its requirings",
-						util.LogCircuitBreakerEndpoint, name,
-						util.LogCircuitBreakerOldStatus, from,
-						util.LogCircuitBreakerNewStatus, to,
-					)
+			recovering := isRecovery(from, to)
+			if !recovering && to != circuitbreaker.StateOpen && to != circuitbreaker.StateForcedOpen {
+				return
+			}
 
-					err := s.repository.SetWithExp(
-						context.Background(),
-						util.FormEndpointStatusKey(name),
-						to.String(),
-						time.Duration(util.GetIntEnv("CB_TIMEOUT", 60))*time.Second,
-					)
-					if err != nil {
-						level.Error(s.log).Log(
-							util.LogMessage, "failed to set circuit breaker status to db",
-							util.LogError, err,
-							util.LogCircuitBreakerEndpoint, name,
-							util.LogCircuitBreakerNewStatus, to.String(),
-						)
-					}
-
-					encodedTopic := util.EncodeTopic(name)
-					message := &protobuf.Status{
-						Endpoint:  name,
-						Status:    to.String(),
-						Timeout:   uint32(util.GetIntEnv("CB_TIMEOUT", 60)),
-						Timestamp: time.Now().Format(time.RFC3339),
-					}
-					if err != nil {
-						level.Error(s.log).Log(
-							util.LogMessage, "failed to marshal circuit breaker status",
-							util.LogError, err,
-							util.LogCircuitBreakerEndpoint, name,
-							util.LogCircuitBreakerNewStatus, to.String(),
-						)
-					}
-
-					err = s.broker.Publish(context.Background(), encodedTopic, message)
-					if err != nil {
-						level.Error(s.log).Log(
-							util.LogMessage, "failed to publish circuit breaker status",
-							util.LogError, err,
-							util.LogCircuitBreakerEndpoint, name,
-							util.LogCircuitBreakerNewStatus, to.String(),
-						)
-					} else {
-						level.Info(s.log).Log(
-							util.LogMessage, "published circuit breaker status",
-							util.LogStatus, message,
-						)
-					}
-				}
+			// a metrics-only breaker reports its trips but never cascades them
+			if cb.MetricsOnly() {
+				level.Info(s.log).Log(
+					util.LogMessage, "circuit breaker is in metrics-only mode, not publishing its status",
+					util.LogCircuitBreakerEndpoint, name,
+					util.LogCircuitBreakerNewStatus, to,
+				)
+				return
 			}
+
+			// the publications run in the order of the transitions, so a recovery cannot overtake a later trip
+			if recovering {
+				s.publishInOrder(func() {
+					s.publishRecovery(context.Background(), name, to)
+				})
+				return
+			}
+
+			status := broker.NewStatus(name, to.String(), timeout)
+			status.Reason = newTripReason(cb.TripReason(), breakerConfig)
+			s.publishInOrder(func() {
+				s.publishTrip(context.Background(), status, timeout)
+			})
 		},
 	}
 
-	cb := circuitbreaker.NewCircuitBreaker(st)
+	// endpoints with a sliding window trip on their failure or slow call rate instead of consecutive failures
+	if breakerConfig.SlidingWindow != nil {
+		windowType, _ := circuitbreaker.ParseSlidingWindowType(breakerConfig.SlidingWindow.Type)
+		if windowType != circuitbreaker.SlidingWindowNone {
+			st.ReadyToTrip = nil
+			st.SlidingWindowType = windowType
+			st.SlidingWindowSize = breakerConfig.SlidingWindow.Size
+			st.FailureRateThreshold = breakerConfig.SlidingWindow.FailureRateThreshold
+			st.MinimumNumberOfCalls = breakerConfig.SlidingWindow.MinimumNumberOfCalls
+			st.SlowCallDurationThreshold = breakerConfig.SlidingWindow.SlowCallDurationThreshold
+			st.SlowCallRateThreshold = breakerConfig.SlidingWindow.SlowCallRateThreshold
+		}
+	}
+
+	if breakerConfig.Backoff != nil {
+		st.Backoff = &circuitbreaker.BackoffPolicy{
+			Base:       breakerConfig.Backoff.Base,
+			Multiplier: breakerConfig.Backoff.Multiplier,
+			Cap:        breakerConfig.Backoff.Cap,
+			Jitter:     breakerConfig.Backoff.Jitter,
+		}
+	}
+
+	st.MetricsOnly = breakerConfig.Mode == config.BreakerModeMetricsOnly
+
+	cb = circuitbreaker.NewCircuitBreaker(st)
+	if breakerConfig.Mode == config.BreakerModeDisabled {
+		cb.Disable()
+	}
 	s.breakers[name] = cb
 	return cb
 }
+
+// publishTrip stores the status of a local trip and publishes it to the topics of the endpoint and of its requiring
+// endpoints, or only to the topic of the endpoint while it still has an available alternative
+func (s *service) publishTrip(ctx context.Context, status *protobuf.Status, timeout time.Duration) {
+	name := status.Endpoint
+
+	isThereAlt := false
+	if alt, ok := s.config.AlternativeEndpoints[name]; ok {
+		for _, ep := range alt.Alternatives {
+			endpointName := util.FormEndpointName(ep.Endpoint, ep.Method)
+			_, err := s.repository.Get(ctx, util.FormEndpointStatusKey(endpointName))
+			if err != nil {
+				if errors.Is(err, util.ErrKeyNotFound) {
+					isThereAlt = true
+					break
+				} else {
+					level.Error(s.log).Log(
+						util.LogMessage, "failed to get endpoint status from db",
+						util.LogEndpoint, endpointName,
+						util.LogError, err,
+					)
+				}
+			}
+		}
+	}
+
+	if isThereAlt {
+		level.Info(s.log).Log(
+			util.LogMessage, "there are still alternative endpoints, only publishing the endpoint not its requirings",
+			util.LogCircuitBreakerEndpoint, name,
+			util.LogCircuitBreakerNewStatus, status.Status,
+		)
+
+		s.storeStatus(ctx, name, status, timeout)
+		s.publishStatus(ctx, name, status)
+		return
+	}
+
+	requiringEndpoints, err := s.repository.GetMemberOfSet(ctx, util.FormRequiringEndpointsKey(name))
+	if err != nil {
+		level.Error(s.log).Log(
+			util.LogMessage, "failed to get requiring endpoints from db",
+			util.LogError, err,
+			util.LogCircuitBreakerEndpoint, name,
+			util.LogCircuitBreakerNewStatus, status.Status,
+		)
+
+		s.storeStatus(ctx, name, status, timeout)
+		return
+	}
+
+	for _, ep := range requiringEndpoints {
+		// the endpoint itself is among its requirings and gets the status of its own trip
+		message := status
+		if ep != name {
+			message = broker.CascadeStatus(status, ep, timeout)
+		}
+
+		s.publishStatus(ctx, ep, message)
+		s.storeStatus(ctx, ep, status, timeout)
+	}
+}
+
+// storeStatus stores the status of the endpoint along with its root cause, so only the recovery of that root cause
+// clears it
+func (s *service) storeStatus(ctx context.Context, endpoint string, status *protobuf.Status, timeout time.Duration) {
+	err := s.repository.SetWithExp(ctx, util.FormEndpointStatusKey(endpoint), status.Status, timeout)
+	if err == nil {
+		err = s.repository.SetWithExp(ctx, util.FormRootCauseKey(endpoint), broker.RootCauseEndpoint(status), timeout)
+	}
+	if err != nil {
+		level.Error(s.log).Log(
+			util.LogMessage, "failed to set circuit breaker status to db",
+			util.LogError, err,
+			util.LogCircuitBreakerEndpoint, endpoint,
+			util.LogCircuitBreakerNewStatus, status.Status,
+		)
+	}
+}
+
+func (s *service) publishStatus(ctx context.Context, endpoint string, message *protobuf.Status) {
+	err := s.broker.Publish(ctx, util.EncodeTopic(endpoint), message)
+	if err != nil {
+		level.Error(s.log).Log(
+			util.LogMessage, "failed to publish circuit breaker status",
+			util.LogError, err,
+			util.LogCircuitBreakerEndpoint, endpoint,
+			util.LogCircuitBreakerNewStatus, message.Status,
+		)
+	} else {
+		level.Info(s.log).Log(
+			util.LogMessage, "published circuit breaker status",
+			util.LogStatus, message,
+		)
+	}
+}
+
+// publishInOrder runs the publication after those of the previous transitions of the local circuit breakers
+func (s *service) publishInOrder(publish func()) {
+	s.publishOnce.Do(func() {
+		s.publishQueue = make(chan func(), publishQueueSize)
+		go func() {
+			for publish := range s.publishQueue {
+				publish()
+			}
+		}()
+	})
+	s.publishQueue <- publish
+}
+
+// newTripReason converts the reason of a trip for a status, the threshold of the consecutive failures policy
+// comes from the config since ReadyToTrip hides it from the circuit breaker
+func newTripReason(reason circuitbreaker.TripReason, breakerConfig config.Breaker) *protobuf.TripReason {
+	if reason.Cause == "" {
+		return nil
+	}
+
+	threshold := reason.Threshold
+	if reason.Cause == circuitbreaker.TripCauseReadyToTrip {
+		threshold = float64(breakerConfig.MaxConsecutiveFailures)
+	}
+
+	return &protobuf.TripReason{
+		Cause:               string(reason.Cause),
+		Requests:            reason.Counts.Requests,
+		TotalFailures:       reason.Counts.TotalFailures,
+		ConsecutiveFailures: reason.Counts.ConsecutiveFailures,
+		WindowRequests:      reason.Counts.WindowRequests,
+		WindowFailures:      reason.Counts.WindowFailures,
+		WindowSlowCalls:     reason.Counts.WindowSlowCalls,
+		Threshold:           threshold,
+	}
+}
+
+// isRecovery reports whether the transition lets requests through an endpoint that was rejecting them
+func isRecovery(from, to circuitbreaker.State) bool {
+	wasOpen := from == circuitbreaker.StateOpen || from == circuitbreaker.StateHalfOpen || from == circuitbreaker.StateForcedOpen
+	return wasOpen && (to == circuitbreaker.StateHalfOpen || to == circuitbreaker.StateClosed)
+}
+
+// publishRecovery clears the statuses caused by the endpoint, its own and those of its requiring endpoints, and
+// publishes the recovery to their topics so the other sidecars clear them too instead of waiting for them to expire.
+// The statuses with another root cause still hold and are not published.
+func (s *service) publishRecovery(ctx context.Context, name string, state circuitbreaker.State) {
+	requiringEndpoints, err := s.repository.GetMemberOfSet(ctx, util.FormRequiringEndpointsKey(name))
+	if err != nil {
+		level.Error(s.log).Log(
+			util.LogMessage, "failed to get requiring endpoints from db",
+			util.LogError, err,
+			util.LogCircuitBreakerEndpoint, name,
+			util.LogCircuitBreakerNewStatus, state.String(),
+		)
+	}
+
+	status := broker.NewStatus(name, state.String(), 0)
+
+	endpoints := []string{name}
+	for _, ep := range requiringEndpoints {
+		if ep != name {
+			endpoints = append(endpoints, ep)
+		}
+	}
+
+	for _, ep := range endpoints {
+		isCaused, err := broker.IsCausedBy(ctx, s.repository.Get, ep, name)
+		if err != nil {
+			level.Error(s.log).Log(
+				util.LogMessage, "failed to get circuit breaker root cause from db",
+				util.LogError, err,
+				util.LogCircuitBreakerEndpoint, ep,
+			)
+		}
+		if !isCaused {
+			level.Info(s.log).Log(
+				util.LogMessage, "circuit breaker status has another root cause, keeping it",
+				util.LogCircuitBreakerEndpoint, ep,
+				util.LogRootCauseEndpoint, name,
+			)
+			continue
+		}
+
+		err = s.repository.Delete(ctx, util.FormEndpointStatusKey(ep), util.FormRootCauseKey(ep))
+		if err != nil {
+			level.Error(s.log).Log(
+				util.LogMessage, "failed to delete circuit breaker status from db",
+				util.LogError, err,
+				util.LogCircuitBreakerEndpoint, ep,
+			)
+		}
+
+		message := status
+		if ep != name {
+			message = broker.CascadeStatus(status, ep, 0)
+		}
+		s.publishStatus(ctx, ep, message)
+	}
+}
@@ -35,6 +35,8 @@ func (s *service) initConfig(ctx context.Context) {
 				Set:          s.repository.SetWithExp,
 				Get:          s.repository.Get,
 				GetSetMember: s.repository.GetMemberOfSet,
+				Delete:       s.repository.Delete,
+				Notify:       s.notifyStatus,
 			})
 			s.subscribeMap[endpointName] = true
 		}
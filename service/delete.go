@@ -16,6 +16,8 @@ type DeleteRequest struct {
 }
 
 func (s *service) Delete(ctx context.Context, req *DeleteRequest) (*Response, error) {
+	ctx, req.RequiringEndpoint, req.RequiringMethod = s.requiringFromBaggage(ctx, req.RequiringEndpoint, req.RequiringMethod)
+
 	if err := s.validator.Struct(req); err != nil {
 		level.Error(s.log).Log(
 			util.LogMessage, "failed precondition on request",
@@ -18,6 +18,8 @@ type GeneralRequest struct {
 }
 
 func (s *service) General(ctx context.Context, req *GeneralRequest) (*Response, error) {
+	ctx, req.RequiringEndpoint, req.RequiringMethod = s.requiringFromBaggage(ctx, req.RequiringEndpoint, req.RequiringMethod)
+
 	if err := s.validator.Struct(req); err != nil {
 		level.Error(s.log).Log(
 			util.LogMessage, "failed precondition on request",
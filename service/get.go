@@ -16,6 +16,8 @@ type GetRequest struct {
 }
 
 func (s *service) Get(ctx context.Context, req *GetRequest) (*Response, error) {
+	ctx, req.RequiringEndpoint, req.RequiringMethod = s.requiringFromBaggage(ctx, req.RequiringEndpoint, req.RequiringMethod)
+
 	if err := s.validator.Struct(req); err != nil {
 		level.Error(s.log).Log(
 			util.LogMessage, "failed precondition on request",
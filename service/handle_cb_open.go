@@ -20,18 +20,29 @@ func (s *service) handleCircuitBreakerOpen(ctx context.Context, circuitBreakerNa
 		res, err := s.executeAlternativeEndpoint(ctx, &executeAlternativeEndpointReq{
 			Request:             req,
 			AlternativeEndpoint: altEndpoint,
-			Body:                req.Body,
-			Header:              req.Header,
+			Header:              req.header(),
 		})
 		if err != nil {
-			return &Response{}, status.Error(codes.Internal, util.ErrFailedExecuteAltEndpoint.Error())
+			if res, ok := failedResponse(res, err); ok {
+				res.IsFromAlternativeEndpoint = true
+				return res, nil
+			}
+			return &Response{}, executeError(err, util.ErrFailedExecuteAltEndpoint)
 		}
 		res.IsFromAlternativeEndpoint = true
 		return res, nil
 	} else if isException {
-		res, err := s.httpRequest(ctx, req.Method, req.URL, req.Body, req.Header)
+		body, err := req.body()
 		if err != nil {
-			return &Response{}, status.Error(codes.Internal, util.ErrFailedExecuteRequest.Error())
+			return &Response{}, executeError(err, util.ErrFailedExecuteRequest)
+		}
+
+		res, err := s.httpRequest(ctx, req.Method, req.URL, body, req.header(), req.Stream)
+		if err != nil {
+			if res, ok := failedResponse(res, err); ok {
+				return res, nil
+			}
+			return &Response{}, executeError(err, util.ErrFailedExecuteRequest)
 		}
 		return res, nil
 	} else {
@@ -1,22 +1,41 @@
 package service
 
 import (
-	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"github.com/daffarg/distributed-cascading-cb/config"
 	"github.com/daffarg/distributed-cascading-cb/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 )
 
-func (s *service) httpRequest(ctx context.Context, method, url string, body []byte, header map[string]string) (*Response, error) {
+// httpRequest sends the request and reads its response, a streamed response is returned with its body unread.
+// A streamed request is sent with the stream client, which has no overall timeout since it would cut its body off.
+func (s *service) httpRequest(ctx context.Context, method, url string, reqBody io.Reader, header http.Header, stream bool) (*Response, error) {
 	client := s.httpClient
-	req, err := http.NewRequest(method, url, bytes.NewBuffer(body))
+	if stream {
+		client = s.streamClient
+	}
+	req, err := http.NewRequest(method, url, reqBody)
 	if err != nil {
 		return nil, err
 	}
 
 	for k, v := range header {
-		req.Header.Set(k, v)
+		req.Header[k] = append([]string(nil), v...)
+	}
+
+	// the length of a streamed body is only known from the header of the caller, it is sent chunked otherwise
+	if stream && reqBody != http.NoBody {
+		if contentLength, err := strconv.ParseInt(req.Header.Get("Content-Length"), 10, 64); err == nil {
+			req.ContentLength = contentLength
+		}
 	}
 
 	req = req.Clone(ctx)
@@ -26,14 +45,14 @@ func (s *service) httpRequest(ctx context.Context, method, url string, body []by
 		return nil, err
 	}
 
-	if httpRes.StatusCode >= 500 {
-		return nil, util.ErrFailedExecuteRequest
-	}
-	defer httpRes.Body.Close()
+	var body []byte
+	if !stream {
+		defer httpRes.Body.Close()
 
-	body, err = io.ReadAll(httpRes.Body)
-	if err != nil {
-		return &Response{}, err
+		body, err = util.ReadAllLimited(httpRes.Body, util.GetMaxBufferedBodySize())
+		if err != nil {
+			return &Response{}, err
+		}
 	}
 
 	res := &Response{
@@ -46,11 +65,115 @@ func (s *service) httpRequest(ctx context.Context, method, url string, body []by
 		ContentLength: httpRes.ContentLength,
 	}
 
-	responseHeader := make(map[string]string)
-	for k := range header {
-		responseHeader[k] = httpRes.Header.Get(k)
+	headers := httpRes.Header.Clone()
+	util.RemoveHopByHopHeaders(headers)
+	res.Header = s.convertResponseHeader(headers)
+	res.Headers = headers
+	// the trailers are only known once the body has been read
+	if stream {
+		res.BodyStream = &trailerReader{ReadCloser: httpRes.Body, httpRes: httpRes, res: res}
+	} else if len(httpRes.Trailer) > 0 {
+		res.Trailers = httpRes.Trailer.Clone()
+	}
+
+	// the response is still returned so that the caller gets the upstream error, the body of a streamed response
+	// is not matched against the failure body pattern
+	classification := s.getClassification(method, url)
+	if classification.IsFailureResponse(httpRes.StatusCode, httpRes.Header, body) {
+		return res, &failureResponseError{
+			statusCode: httpRes.StatusCode,
+			retryAfter: classification.RetryAfter(httpRes.StatusCode, httpRes.Header, time.Now()),
+		}
 	}
-	res.Header = responseHeader
 
 	return res, nil
 }
+
+// trailerReader sets the trailers of a streamed response once its body is read
+type trailerReader struct {
+	io.ReadCloser
+	httpRes *http.Response
+	res     *Response
+}
+
+func (r *trailerReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if errors.Is(err, io.EOF) && len(r.httpRes.Trailer) > 0 {
+		r.res.Trailers = r.httpRes.Trailer.Clone()
+	}
+	return n, err
+}
+
+// getClassification returns the configured classification of the requested endpoint
+func (s *service) getClassification(method, url string) *config.Classification {
+	parsedUrl, _ := util.GetGeneralURLFormat(strings.ToLower(url))
+	endpointName := util.FormEndpointName(parsedUrl, strings.ToUpper(method))
+	return s.config.GetBreaker(endpointName).Classification
+}
+
+// failureResponseError is returned for an upstream response classified as a failure
+type failureResponseError struct {
+	statusCode int
+	retryAfter time.Duration
+}
+
+func (e *failureResponseError) Error() string {
+	return fmt.Sprintf("%s: upstream responded with status code %d", util.ErrFailedExecuteRequest, e.statusCode)
+}
+
+func (e *failureResponseError) Unwrap() error {
+	return util.ErrFailedExecuteRequest
+}
+
+// failedResponse returns the upstream response of a request that failed on its response, marked as a failure,
+// so it is forwarded to the caller while the circuit breaker counts it as a failure
+func failedResponse(result interface{}, err error) (*Response, bool) {
+	var resErr *failureResponseError
+	res, ok := result.(*Response)
+	if !ok || res == nil || !errors.As(err, &resErr) {
+		return nil, false
+	}
+
+	res.IsFailure = true
+	return res, true
+}
+
+// executeError returns the gRPC error of a request that failed to execute
+func executeError(err error, failure error) error {
+	if errors.Is(err, util.ErrBodyTooLarge) {
+		return status.Error(codes.ResourceExhausted, util.ErrBodyTooLarge.Error())
+	}
+	return status.Error(codes.Internal, failure.Error())
+}
+
+// closeResponse closes the body of a streamed response that is not returned to the caller
+func closeResponse(res *Response) {
+	if res != nil && res.BodyStream != nil {
+		res.BodyStream.Close()
+	}
+}
+
+// isSuccessful counts the failed responses and the classified transport errors of a request as failures
+func isSuccessful(classification *config.Classification) func(err error) bool {
+	return func(err error) bool {
+		var resErr *failureResponseError
+		if errors.As(err, &resErr) {
+			return false
+		}
+		// the upstream is not at fault when its body cannot be buffered or the body of the caller cannot be sent
+		var bodyErr *requestBodyError
+		if errors.Is(err, util.ErrBodyTooLarge) || errors.Is(err, util.ErrBodyAlreadySent) || errors.As(err, &bodyErr) {
+			return true
+		}
+		return !classification.IsFailureError(err)
+	}
+}
+
+// retryAfter opens the circuit breaker for as long as the upstream asked to wait
+func retryAfter(err error) (time.Duration, bool) {
+	var resErr *failureResponseError
+	if errors.As(err, &resErr) && resErr.retryAfter > 0 {
+		return resErr.retryAfter, true
+	}
+	return 0, false
+}
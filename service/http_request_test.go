@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/daffarg/distributed-cascading-cb/config"
+)
+
+func Test_service_httpRequest_stream(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("first"))
+		w.(http.Flusher).Flush()
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte(" second"))
+	}))
+	defer upstream.Close()
+
+	s := &service{
+		httpClient:   &http.Client{Timeout: 50 * time.Millisecond},
+		streamClient: &http.Client{},
+		config:       config.NewConfig(),
+	}
+
+	tests := []struct {
+		name     string
+		stream   bool
+		wantBody string
+		wantErr  bool
+	}{
+		{name: "Buffered_body_cut_off_by_timeout", stream: false, wantErr: true},
+		{name: "Streamed_body_outlasts_timeout", stream: true, wantBody: "first second"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.httpRequest(context.Background(), http.MethodGet, upstream.URL, http.NoBody, nil, tt.stream)
+			if err == nil && tt.stream {
+				defer res.BodyStream.Close()
+				var body []byte
+				body, err = io.ReadAll(res.BodyStream)
+				res.Body = body
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("httpRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && string(res.Body) != tt.wantBody {
+				t.Errorf("httpRequest() body = %q, want %q", res.Body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -17,6 +17,8 @@ type PostRequest struct {
 }
 
 func (s *service) Post(ctx context.Context, req *PostRequest) (*Response, error) {
+	ctx, req.RequiringEndpoint, req.RequiringMethod = s.requiringFromBaggage(ctx, req.RequiringEndpoint, req.RequiringMethod)
+
 	if err := s.validator.Struct(req); err != nil {
 		level.Error(s.log).Log(
 			util.LogMessage, "failed precondition on request",
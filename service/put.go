@@ -17,6 +17,8 @@ type PutRequest struct {
 }
 
 func (s *service) Put(ctx context.Context, req *PutRequest) (*Response, error) {
+	ctx, req.RequiringEndpoint, req.RequiringMethod = s.requiringFromBaggage(ctx, req.RequiringEndpoint, req.RequiringMethod)
+
 	if err := s.validator.Struct(req); err != nil {
 		level.Error(s.log).Log(
 			util.LogMessage, "failed precondition on request",
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"github.com/daffarg/distributed-cascading-cb/circuitbreaker"
@@ -8,6 +9,8 @@ import (
 	"github.com/go-kit/log/level"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"io"
+	"net/http"
 	"strings"
 	"time"
 )
@@ -19,6 +22,69 @@ type request struct {
 	Body              []byte            `json:"body"`
 	RequiringEndpoint string            `json:"requiring_endpoint"`
 	RequiringMethod   string            `json:"requiring_method"`
+	// Headers carries every value of the request headers, Header only adds the headers it lacks
+	Headers http.Header `json:"-"`
+	// Stream sends BodyStream instead of Body and leaves the body of the response unread
+	Stream     bool      `json:"stream"`
+	BodyStream io.Reader `json:"-"`
+	bodySent   bool
+}
+
+// header returns the headers to send for the request
+func (r *request) header() http.Header {
+	header := r.Headers.Clone()
+	if header == nil {
+		header = make(http.Header, len(r.Header))
+	}
+	for k, v := range r.Header {
+		if _, ok := header[http.CanonicalHeaderKey(k)]; !ok {
+			header.Set(k, v)
+		}
+	}
+	return header
+}
+
+// body returns the body to send for the request, a streamed body can only be sent once
+func (r *request) body() (io.Reader, error) {
+	if !r.Stream {
+		return bytes.NewReader(r.Body), nil
+	}
+
+	if r.bodySent {
+		return nil, util.ErrBodyAlreadySent
+	}
+	r.bodySent = true
+
+	if r.BodyStream == http.NoBody {
+		return http.NoBody, nil
+	}
+	return &requestBodyReader{Reader: r.BodyStream}, nil
+}
+
+// requestBodyReader marks the errors of the caller while reading a streamed body so they are not counted as failures
+// of the upstream
+type requestBodyReader struct {
+	io.Reader
+}
+
+func (r *requestBodyReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) {
+		err = &requestBodyError{err: err}
+	}
+	return n, err
+}
+
+type requestBodyError struct {
+	err error
+}
+
+func (e *requestBodyError) Error() string {
+	return e.err.Error()
+}
+
+func (e *requestBodyError) Unwrap() error {
+	return e.err
 }
 
 func (s *service) requestWithCircuitBreaker(ctx context.Context, req *request) (*Response, error) {
@@ -32,6 +98,10 @@ func (s *service) requestWithCircuitBreaker(ctx context.Context, req *request) (
 		return &Response{}, status.Error(codes.Internal, util.ErrFailedParsingURL.Error())
 	}
 
+	if !req.Stream && int64(len(req.Body)) > util.GetMaxBufferedBodySize() {
+		return &Response{}, status.Error(codes.ResourceExhausted, util.ErrBodyTooLarge.Error())
+	}
+
 	req.URL = strings.ToLower(req.URL)
 	req.Method = strings.ToUpper(req.Method)
 	req.RequiringEndpoint = strings.ToLower(req.RequiringEndpoint)
@@ -40,6 +110,10 @@ func (s *service) requestWithCircuitBreaker(ctx context.Context, req *request) (
 	circuitBreakerName := util.FormEndpointName(parsedUrl, req.Method)
 	endpointStatusKey := util.FormEndpointStatusKey(circuitBreakerName)
 
+	// a disabled or metrics-only breaker lets every request through, including those a cascaded status would reject
+	cb := s.getCircuitBreaker(circuitBreakerName)
+	isBypassed := cb.State() == circuitbreaker.StateDisabled || cb.MetricsOnly()
+
 	isAlreadySubscribed := false
 	_, ok := s.subscribeMap[circuitBreakerName]
 	if ok {
@@ -89,22 +163,14 @@ func (s *service) requestWithCircuitBreaker(ctx context.Context, req *request) (
 
 			timestamp, _ := time.Parse(time.RFC3339, msg.Timestamp)
 			expiredTime := timestamp.Add(time.Duration(msg.Timeout) * time.Second)
-			if time.Now().Before(expiredTime) && msg.Status == circuitbreaker.StateOpen.String() {
-				timeout := expiredTime.Sub(time.Now()) * time.Second
-				go func() {
-					err = s.repository.SetWithExp(context.WithoutCancel(ctx), util.FormEndpointStatusKey(msg.Endpoint), msg.Status, timeout)
-					if err != nil {
-						level.Error(s.log).Log(
-							util.LogMessage, "failed to store circuit breaker status into db",
-							util.LogError, err,
-							util.LogRequest, req,
-							util.LogTopic, topic,
-							util.LogStatus, msg,
-						)
-					}
-				}()
-
-				return s.handleCircuitBreakerOpen(ctx, circuitBreakerName, req)
+			isOpen := msg.Status == circuitbreaker.StateOpen.String() || msg.Status == circuitbreaker.StateForcedOpen.String()
+			if time.Now().Before(expiredTime) && isOpen {
+				timeout := time.Until(expiredTime)
+				go s.storeStatus(context.WithoutCancel(ctx), msg.Endpoint, msg, timeout)
+
+				if !isBypassed {
+					return s.handleCircuitBreakerOpen(ctx, circuitBreakerName, req)
+				}
 			}
 		}
 	}
@@ -123,8 +189,8 @@ func (s *service) requestWithCircuitBreaker(ctx context.Context, req *request) (
 	})
 
 	_, err = s.repository.Get(ctx, endpointStatusKey)
-	if err != nil {
-		if !errors.Is(err, util.ErrKeyNotFound) {
+	if err != nil || isBypassed {
+		if err != nil && !errors.Is(err, util.ErrKeyNotFound) {
 			level.Error(s.log).Log(
 				util.LogMessage, "failed to get cb status from db",
 				util.LogError, err,
@@ -133,14 +199,21 @@ func (s *service) requestWithCircuitBreaker(ctx context.Context, req *request) (
 		}
 
 		// do request if error when getting cb status or cb status is not open
-		response, err := s.getCircuitBreaker(circuitBreakerName).Execute(func() (interface{}, error) {
-			return s.httpRequest(ctx, req.Method, req.URL, req.Body, req.Header)
+		response, err := cb.Execute(func() (interface{}, error) {
+			body, err := req.body()
+			if err != nil {
+				return nil, err
+			}
+			return s.httpRequest(ctx, req.Method, req.URL, body, req.header(), req.Stream)
 		})
 		if err != nil {
 			if errors.Is(err, circuitbreaker.ErrOpenState) {
 				return s.handleCircuitBreakerOpen(ctx, circuitBreakerName, req)
 			}
-			return &Response{}, status.Error(codes.Internal, util.ErrFailedExecuteRequest.Error())
+			if res, ok := failedResponse(response, err); ok {
+				return res, nil
+			}
+			return &Response{}, executeError(err, util.ErrFailedExecuteRequest)
 		}
 
 		return response.(*Response), nil
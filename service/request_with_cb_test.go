@@ -107,7 +107,7 @@ func Test_service_requestWithCircuitBreaker(t *testing.T) {
 					mockBroker.EXPECT().Subscribe(gomock.Any(), gomock.Any()).Return(nil, util.ErrUpdatedStatusNotFound)
 					mockRepository.EXPECT().AddMembersIntoSet(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(1), nil).AnyTimes()
 					mockRepository.EXPECT().AddMembersIntoSet(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(1), nil).AnyTimes()
-					mockBroker.EXPECT().SubscribeAsync(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+					mockBroker.EXPECT().SubscribeAsync(gomock.Any()).AnyTimes()
 					mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", util.ErrKeyNotFound)
 				},
 			},
@@ -120,6 +120,7 @@ func Test_service_requestWithCircuitBreaker(t *testing.T) {
 				ProtoMajor:                0,
 				ProtoMinor:                0,
 				Header:                    make(map[string]string),
+				Headers:                   http.Header{},
 				ContentLength:             -1,
 				IsFromAlternativeEndpoint: false,
 			},
@@ -153,7 +154,7 @@ func Test_service_requestWithCircuitBreaker(t *testing.T) {
 					mockBroker.EXPECT().Subscribe(gomock.Any(), gomock.Any()).Return(nil, errors.New("failed to subscribe"))
 					mockRepository.EXPECT().AddMembersIntoSet(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(1), nil).AnyTimes()
 					mockRepository.EXPECT().AddMembersIntoSet(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(1), nil).AnyTimes()
-					mockBroker.EXPECT().SubscribeAsync(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+					mockBroker.EXPECT().SubscribeAsync(gomock.Any()).AnyTimes()
 					mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", util.ErrKeyNotFound)
 				},
 			},
@@ -166,6 +167,7 @@ func Test_service_requestWithCircuitBreaker(t *testing.T) {
 				ProtoMajor:                0,
 				ProtoMinor:                0,
 				Header:                    make(map[string]string),
+				Headers:                   http.Header{},
 				ContentLength:             -1,
 				IsFromAlternativeEndpoint: false,
 			},
@@ -208,7 +210,7 @@ func Test_service_requestWithCircuitBreaker(t *testing.T) {
 					mockRepository.EXPECT().AddMembersIntoSet(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(1), nil).AnyTimes()
 					mockRepository.EXPECT().AddMembersIntoSet(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(1), nil).AnyTimes()
 					mockRepository.EXPECT().SetWithExp(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
-					mockBroker.EXPECT().SubscribeAsync(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+					mockBroker.EXPECT().SubscribeAsync(gomock.Any()).AnyTimes()
 				},
 			},
 			wantErr: true,
@@ -256,6 +258,7 @@ func Test_service_requestWithCircuitBreaker(t *testing.T) {
 				ProtoMajor:                0,
 				ProtoMinor:                0,
 				Header:                    make(map[string]string),
+				Headers:                   http.Header{},
 				ContentLength:             -1,
 				IsFromAlternativeEndpoint: false,
 			},
@@ -302,6 +305,7 @@ func Test_service_requestWithCircuitBreaker(t *testing.T) {
 				ProtoMajor:                0,
 				ProtoMinor:                0,
 				Header:                    make(map[string]string),
+				Headers:                   http.Header{},
 				ContentLength:             -1,
 				IsFromAlternativeEndpoint: false,
 			},
@@ -337,6 +341,57 @@ func Test_service_requestWithCircuitBreaker(t *testing.T) {
 			wantErr: true,
 			want:    &Response{},
 		},
+		{
+			name: "Status_open_breaker_disabled",
+			fields: fields{
+				log:       svcLog,
+				validator: reqValidator,
+				breakers: func() map[string]*circuitbreaker.CircuitBreaker {
+					cb := circuitbreaker.NewCircuitBreaker(circuitbreaker.Settings{Name: "GET:localhost:8081/hello"})
+					cb.Disable()
+					return map[string]*circuitbreaker.CircuitBreaker{"GET:localhost:8081/hello": cb}
+				}(),
+				subscribeMap: map[string]bool{
+					"GET:localhost:8081/hello": true,
+				},
+				config:     config.NewConfig(),
+				httpClient: &http.Client{},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &request{
+					Method:            "GET",
+					URL:               "http://localhost:8081/hello",
+					Header:            map[string]string{},
+					Body:              []byte{},
+					RequiringEndpoint: "http://localhost:8080/hello",
+					RequiringMethod:   "GET",
+				},
+				mockFunc: func(ctrl *gomock.Controller, mockRepository *mock.MockRepository, mockBroker *mock.MockMessageBroker) {
+					httpmock.Activate()
+
+					httpmock.RegisterResponder("GET", "http://localhost:8081/hello",
+						httpmock.NewStringResponder(200, `{"status":"ok"}`))
+
+					mockRepository.EXPECT().AddMembersIntoSet(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), nil).AnyTimes()
+					mockRepository.EXPECT().AddMembersIntoSet(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), nil).AnyTimes()
+					mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return("open", nil)
+				},
+			},
+			wantErr: false,
+			want: &Response{
+				Body:                      []byte(`{"status":"ok"}`),
+				Status:                    "200",
+				StatusCode:                http.StatusOK,
+				Proto:                     "",
+				ProtoMajor:                0,
+				ProtoMinor:                0,
+				Header:                    make(map[string]string),
+				Headers:                   http.Header{},
+				ContentLength:             -1,
+				IsFromAlternativeEndpoint: false,
+			},
+		},
 		{
 			name: "Status_open_available_alt",
 			fields: fields{
@@ -393,6 +448,7 @@ func Test_service_requestWithCircuitBreaker(t *testing.T) {
 				ProtoMajor:                0,
 				ProtoMinor:                0,
 				Header:                    make(map[string]string),
+				Headers:                   http.Header{},
 				ContentLength:             -1,
 				IsFromAlternativeEndpoint: true,
 			},
@@ -446,12 +502,13 @@ func Test_service_requestWithCircuitBreaker(t *testing.T) {
 				ProtoMajor:                0,
 				ProtoMinor:                0,
 				Header:                    make(map[string]string),
+				Headers:                   http.Header{},
 				ContentLength:             -1,
 				IsFromAlternativeEndpoint: false,
 			},
 		},
 		{
-			name: "Status_closed_failed_execute",
+			name: "Status_closed_failed_response",
 			fields: fields{
 				log:       svcLog,
 				validator: reqValidator,
@@ -490,8 +547,16 @@ func Test_service_requestWithCircuitBreaker(t *testing.T) {
 					mockRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", util.ErrKeyNotFound)
 				},
 			},
-			wantErr: true,
-			want:    &Response{},
+			wantErr: false,
+			want: &Response{
+				Body:          []byte(`{"status":"failed"}`),
+				Status:        "500",
+				StatusCode:    http.StatusInternalServerError,
+				Header:        make(map[string]string),
+				Headers:       http.Header{},
+				ContentLength: -1,
+				IsFailure:     true,
+			},
 		},
 	}
 	for _, tt := range tests {
@@ -36,6 +36,8 @@ func (s *service) handleRequestedEndpoint(ctx context.Context, req *handleReques
 				Set:          s.repository.SetWithExp,
 				Get:          s.repository.Get,
 				GetSetMember: s.repository.GetMemberOfSet,
+				Delete:       s.repository.Delete,
+				Notify:       s.notifyStatus,
 			})
 	}
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"github.com/daffarg/distributed-cascading-cb/util"
 	"github.com/go-kit/log/level"
+	"go.opentelemetry.io/otel/baggage"
+	"strings"
 )
 
 type handleRequiringEndpointReq struct {
@@ -33,3 +35,32 @@ func (s *service) handleRequiringEndpoint(ctx context.Context, req *handleRequir
 		)
 	}
 }
+
+// requiringFromBaggage returns the requiring endpoint and method carried in the baggage of the incoming request
+// when CB_REQUIRING_FROM_BAGGAGE is enabled, falling back to the explicit ones. The members are removed from the
+// returned context so they are not propagated to the requested endpoint, which is not the one requiring it.
+func (s *service) requiringFromBaggage(ctx context.Context, endpoint, method string) (context.Context, string, string) {
+	if !util.GetBoolEnv("CB_REQUIRING_FROM_BAGGAGE", false) {
+		return ctx, endpoint, method
+	}
+
+	bag := baggage.FromContext(ctx)
+	baggageEndpoint := bag.Member(util.BaggageRequiringEndpoint).Value()
+	baggageMethod := bag.Member(util.BaggageRequiringMethod).Value()
+	if baggageEndpoint == "" || baggageMethod == "" {
+		return ctx, endpoint, method
+	}
+
+	if endpoint != "" && (endpoint != baggageEndpoint || !strings.EqualFold(method, baggageMethod)) {
+		level.Warn(s.log).Log(
+			util.LogMessage, "requiring endpoint of the request differs from its baggage, using the baggage",
+			util.LogEndpoint, util.FormEndpointName(endpoint, method),
+			util.LogRequiringEndpoint, util.FormEndpointName(baggageEndpoint, baggageMethod),
+		)
+	}
+
+	bag = bag.DeleteMember(util.BaggageRequiringEndpoint)
+	bag = bag.DeleteMember(util.BaggageRequiringMethod)
+
+	return baggage.ContextWithBaggage(ctx, bag), baggageEndpoint, baggageMethod
+}
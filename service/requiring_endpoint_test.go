@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/daffarg/distributed-cascading-cb/util"
+	logkit "github.com/go-kit/log"
+	"go.opentelemetry.io/otel/baggage"
+)
+
+func Test_service_requiringFromBaggage(t *testing.T) {
+	withBaggage := func(members ...string) context.Context {
+		var bagMembers []baggage.Member
+		for i := 0; i < len(members); i += 2 {
+			member, _ := baggage.NewMember(members[i], members[i+1])
+			bagMembers = append(bagMembers, member)
+		}
+		bag, _ := baggage.New(bagMembers...)
+		return baggage.ContextWithBaggage(context.Background(), bag)
+	}
+
+	tests := []struct {
+		name         string
+		enabled      string
+		ctx          context.Context
+		wantEndpoint string
+		wantMethod   string
+	}{
+		{
+			name:         "Disabled",
+			enabled:      "false",
+			ctx:          withBaggage(util.BaggageRequiringEndpoint, "http://localhost:8081/from-baggage", util.BaggageRequiringMethod, "POST"),
+			wantEndpoint: "http://localhost:8080/hello",
+			wantMethod:   "GET",
+		},
+		{
+			name:         "From_baggage",
+			enabled:      "true",
+			ctx:          withBaggage(util.BaggageRequiringEndpoint, "http://localhost:8081/from-baggage", util.BaggageRequiringMethod, "POST", "tenant", "a"),
+			wantEndpoint: "http://localhost:8081/from-baggage",
+			wantMethod:   "POST",
+		},
+		{
+			name:         "Fallback_without_method",
+			enabled:      "true",
+			ctx:          withBaggage(util.BaggageRequiringEndpoint, "http://localhost:8081/from-baggage"),
+			wantEndpoint: "http://localhost:8080/hello",
+			wantMethod:   "GET",
+		},
+		{
+			name:         "Fallback_without_baggage",
+			enabled:      "true",
+			ctx:          context.Background(),
+			wantEndpoint: "http://localhost:8080/hello",
+			wantMethod:   "GET",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CB_REQUIRING_FROM_BAGGAGE", tt.enabled)
+			s := &service{log: logkit.NewNopLogger()}
+
+			ctx, endpoint, method := s.requiringFromBaggage(tt.ctx, "http://localhost:8080/hello", "GET")
+			if endpoint != tt.wantEndpoint || method != tt.wantMethod {
+				t.Errorf("requiringFromBaggage() = %v %v, want %v %v", method, endpoint, tt.wantMethod, tt.wantEndpoint)
+			}
+
+			// only the requiring members are dropped before the request goes further
+			bag := baggage.FromContext(ctx)
+			if endpoint != "http://localhost:8080/hello" && bag.Member(util.BaggageRequiringEndpoint).Value() != "" {
+				t.Errorf("requiringFromBaggage() kept %v in the baggage", util.BaggageRequiringEndpoint)
+			}
+			if got := baggage.FromContext(tt.ctx).Member("tenant").Value(); bag.Member("tenant").Value() != got {
+				t.Errorf("requiringFromBaggage() dropped the other members, got %v", bag.Member("tenant").Value())
+			}
+		})
+	}
+}
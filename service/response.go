@@ -12,9 +12,14 @@ type Response struct {
 	ProtoMajor                int32             `json:"proto_major"`
 	ProtoMinor                int32             `json:"proto_minor"`
 	Header                    map[string]string `json:"header"`
+	Headers                   http.Header       `json:"headers"`
+	Trailers                  http.Header       `json:"trailers"`
 	Body                      []byte            `json:"body"`
 	ContentLength             int64             `json:"content_length"`
 	IsFromAlternativeEndpoint bool              `json:"is_from_alternative_endpoint"`
+	IsFailure                 bool              `json:"is_failure"`
+	// BodyStream is the unread body of a streamed response, Body is left empty for them
+	BodyStream io.ReadCloser `json:"-"`
 }
 
 func (s *service) convertToResponse(res *http.Response) (Response, error) {
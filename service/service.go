@@ -5,6 +5,7 @@ import (
 	"github.com/daffarg/distributed-cascading-cb/broker"
 	"github.com/daffarg/distributed-cascading-cb/circuitbreaker"
 	"github.com/daffarg/distributed-cascading-cb/config"
+	"github.com/daffarg/distributed-cascading-cb/protobuf"
 	"github.com/daffarg/distributed-cascading-cb/repository"
 	"github.com/daffarg/distributed-cascading-cb/util"
 	"github.com/go-kit/log"
@@ -12,6 +13,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"go.opentelemetry.io/otel/trace"
 	"net/http"
+	"sync"
 )
 
 type CircuitBreakerService interface {
@@ -20,6 +22,25 @@ type CircuitBreakerService interface {
 	Post(ctx context.Context, req *PostRequest) (*Response, error)
 	Put(ctx context.Context, req *PutRequest) (*Response, error)
 	Delete(ctx context.Context, req *DeleteRequest) (*Response, error)
+	GeneralStream(ctx context.Context, req *StreamRequest) (*Response, error)
+	WatchStatus(ctx context.Context, req *WatchStatusRequest) (<-chan *protobuf.Status, error)
+}
+
+type AdminService interface {
+	ListBreakers(ctx context.Context, req *ListBreakersRequest) (*ListBreakersResponse, error)
+	GetBreaker(ctx context.Context, req *BreakerRequest) (*Breaker, error)
+	ForceOpen(ctx context.Context, req *BreakerRequest) (*Breaker, error)
+	ForceClose(ctx context.Context, req *BreakerRequest) (*Breaker, error)
+	Reset(ctx context.Context, req *BreakerRequest) (*Breaker, error)
+	Disable(ctx context.Context, req *BreakerRequest) (*Breaker, error)
+	SetMetricsOnly(ctx context.Context, req *SetMetricsOnlyRequest) (*Breaker, error)
+	ListRequirings(ctx context.Context, req *BreakerRequest) (*ListRequiringsResponse, error)
+	ExportGraph(ctx context.Context, req *ExportGraphRequest) (*ExportGraphResponse, error)
+}
+
+type Service interface {
+	CircuitBreakerService
+	AdminService
 }
 
 type service struct {
@@ -28,7 +49,12 @@ type service struct {
 	repository   repository.Repository
 	broker       broker.MessageBroker
 	breakers     map[string]*circuitbreaker.CircuitBreaker
+	breakersMu   sync.Mutex
+	watchers     statusWatchers
+	publishQueue chan func()
+	publishOnce  sync.Once
 	httpClient   *http.Client
+	streamClient *http.Client
 	tracer       trace.Tracer
 	config       *config.Config
 	subscribeMap map[string]bool
@@ -40,9 +66,10 @@ func NewCircuitBreakerService(
 	repository repository.Repository,
 	broker broker.MessageBroker,
 	httpClient *http.Client,
+	streamClient *http.Client,
 	tracer trace.Tracer,
 	config *config.Config,
-) CircuitBreakerService {
+) Service {
 	svc := &service{
 		log:          log,
 		validator:    validator,
@@ -50,6 +77,7 @@ func NewCircuitBreakerService(
 		broker:       broker,
 		breakers:     make(map[string]*circuitbreaker.CircuitBreaker),
 		httpClient:   httpClient,
+		streamClient: streamClient,
 		tracer:       tracer,
 		config:       config,
 		subscribeMap: make(map[string]bool),
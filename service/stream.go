@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"github.com/daffarg/distributed-cascading-cb/util"
+	"github.com/go-kit/log/level"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"net/http"
+)
+
+type StreamRequest struct {
+	Method            string            `json:"method" validate:"required"`
+	URL               string            `json:"url" validate:"required"`
+	Header            map[string]string `json:"header"`
+	Headers           http.Header       `json:"-"`
+	Body              io.Reader         `json:"-"`
+	RequiringEndpoint string            `json:"requiring_endpoint" validate:"required"`
+	RequiringMethod   string            `json:"requiring_method" validate:"required"`
+}
+
+// GeneralStream sends the request like General without buffering its body nor the body of its response, which is
+// returned in BodyStream and must be closed by the caller. The trailers of the response are set once its body is read.
+// A streamed body is only sent once, so it is not retried on a second alternative endpoint.
+func (s *service) GeneralStream(ctx context.Context, req *StreamRequest) (*Response, error) {
+	ctx, req.RequiringEndpoint, req.RequiringMethod = s.requiringFromBaggage(ctx, req.RequiringEndpoint, req.RequiringMethod)
+
+	if err := s.validator.Struct(req); err != nil {
+		level.Error(s.log).Log(
+			util.LogMessage, "failed precondition on request",
+			util.LogError, err,
+			util.LogRequest, req,
+		)
+		return &Response{}, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	body := req.Body
+	if body == nil {
+		body = http.NoBody
+	}
+
+	requestQuery := &request{
+		Method:            req.Method,
+		URL:               req.URL,
+		Header:            req.Header,
+		Headers:           req.Headers,
+		RequiringEndpoint: req.RequiringEndpoint,
+		RequiringMethod:   req.RequiringMethod,
+		Stream:            true,
+		BodyStream:        body,
+	}
+
+	return s.requestWithCircuitBreaker(ctx, requestQuery)
+}
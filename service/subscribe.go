@@ -36,6 +36,8 @@ func (s *service) initSubscribe(ctx context.Context) error {
 							Set:          s.repository.SetWithExp,
 							Get:          s.repository.Get,
 							GetSetMember: s.repository.GetMemberOfSet,
+							Delete:       s.repository.Delete,
+							Notify:       s.notifyStatus,
 						})
 					s.subscribeMap[ep] = true
 				}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/daffarg/distributed-cascading-cb/broker"
+	"github.com/daffarg/distributed-cascading-cb/circuitbreaker"
+	"github.com/daffarg/distributed-cascading-cb/protobuf"
+	"github.com/daffarg/distributed-cascading-cb/util"
+	"github.com/go-kit/log/level"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusWatcherBuffer is the number of statuses a watcher may fall behind before new statuses are dropped for it
+const statusWatcherBuffer = 64
+
+type WatchStatusRequest struct {
+	Endpoints []*BreakerRequest `json:"endpoints" validate:"dive"`
+}
+
+type statusWatcher struct {
+	endpoints map[string]bool
+	ch        chan *protobuf.Status
+}
+
+type statusWatchers struct {
+	mu       sync.RWMutex
+	watchers map[*statusWatcher]struct{}
+}
+
+// WatchStatus streams the local transitions and the cascaded statuses of the requested endpoints, or of every endpoint
+// when none is requested, until ctx is done
+func (s *service) WatchStatus(ctx context.Context, req *WatchStatusRequest) (<-chan *protobuf.Status, error) {
+	if err := s.validator.Struct(req); err != nil {
+		level.Error(s.log).Log(
+			util.LogMessage, "failed precondition on request",
+			util.LogError, err,
+			util.LogRequest, req,
+		)
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	watcher := &statusWatcher{
+		endpoints: make(map[string]bool),
+		ch:        make(chan *protobuf.Status, statusWatcherBuffer),
+	}
+	for _, ep := range req.Endpoints {
+		name, err := s.validateBreakerRequest(ep)
+		if err != nil {
+			return nil, err
+		}
+		watcher.endpoints[name] = true
+	}
+
+	s.watchers.mu.Lock()
+	if s.watchers.watchers == nil {
+		s.watchers.watchers = make(map[*statusWatcher]struct{})
+	}
+	s.watchers.watchers[watcher] = struct{}{}
+	s.watchers.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		s.watchers.mu.Lock()
+		delete(s.watchers.watchers, watcher)
+		s.watchers.mu.Unlock()
+
+		close(watcher.ch)
+	}()
+
+	return watcher.ch, nil
+}
+
+// notifyStatus sends the status to every watcher of its endpoint without blocking on slow watchers
+func (s *service) notifyStatus(msg *protobuf.Status) {
+	s.watchers.mu.RLock()
+	defer s.watchers.mu.RUnlock()
+
+	for watcher := range s.watchers.watchers {
+		if len(watcher.endpoints) > 0 && !watcher.endpoints[msg.Endpoint] {
+			continue
+		}
+
+		select {
+		case watcher.ch <- msg:
+		default:
+			level.Warn(s.log).Log(
+				util.LogMessage, "status watcher is too slow, dropping status",
+				util.LogStatus, msg,
+			)
+		}
+	}
+}
+
+// notifyStateChange notifies the watchers of a local circuit breaker transition
+func (s *service) notifyStateChange(name string, state circuitbreaker.State, timeout time.Duration) {
+	if state != circuitbreaker.StateOpen && state != circuitbreaker.StateForcedOpen {
+		timeout = 0
+	}
+	msg := broker.NewStatus(name, state.String(), timeout)
+
+	s.notifyStatus(msg)
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"github.com/daffarg/distributed-cascading-cb/protobuf"
+	"github.com/go-kit/log"
+	"github.com/go-playground/validator/v10"
+	"testing"
+)
+
+func Test_service_WatchStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       *WatchStatusRequest
+		statuses  []*protobuf.Status
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			name:      "No_filter_receives_every_status",
+			req:       &WatchStatusRequest{},
+			statuses:  []*protobuf.Status{{Endpoint: "GET:localhost:8081/hello"}, {Endpoint: "GET:localhost:8082/hello"}},
+			wantNames: []string{"GET:localhost:8081/hello", "GET:localhost:8082/hello"},
+		},
+		{
+			name: "Filter_receives_matching_status",
+			req: &WatchStatusRequest{
+				Endpoints: []*BreakerRequest{{Endpoint: "http://localhost:8082/hello", Method: "get"}},
+			},
+			statuses:  []*protobuf.Status{{Endpoint: "GET:localhost:8081/hello"}, {Endpoint: "GET:localhost:8082/hello"}},
+			wantNames: []string{"GET:localhost:8082/hello"},
+		},
+		{
+			name: "Filter_missing_method",
+			req: &WatchStatusRequest{
+				Endpoints: []*BreakerRequest{{Endpoint: "http://localhost:8082/hello"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &service{
+				log:       log.NewNopLogger(),
+				validator: validator.New(),
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			statuses, err := s.WatchStatus(ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WatchStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				cancel()
+				return
+			}
+
+			for _, msg := range tt.statuses {
+				s.notifyStatus(msg)
+			}
+			cancel()
+
+			var gotNames []string
+			for msg := range statuses {
+				gotNames = append(gotNames, msg.Endpoint)
+			}
+			if len(gotNames) != len(tt.wantNames) {
+				t.Fatalf("WatchStatus() got = %v, want %v", gotNames, tt.wantNames)
+			}
+			for i := range gotNames {
+				if gotNames[i] != tt.wantNames[i] {
+					t.Errorf("WatchStatus() got = %v, want %v", gotNames, tt.wantNames)
+				}
+			}
+		})
+	}
+}
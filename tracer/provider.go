@@ -53,7 +53,7 @@ func NewTracerProvider(ctx context.Context, serviceName, exporterURL string) (*T
 
 func (p *TracerProvider) RegisterAsGlobal() (func(ctx context.Context) error, error) {
 	otel.SetTracerProvider(p.provider)
-	otel.SetTextMapPropagator(propagation.TraceContext{})
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 
 	return p.provider.Shutdown, nil
 }
@@ -0,0 +1,156 @@
+package transport
+
+import (
+	"context"
+
+	"github.com/daffarg/distributed-cascading-cb/endpoint"
+	"github.com/daffarg/distributed-cascading-cb/protobuf"
+	"github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type adminHandler struct {
+	listBreakers   grpc.Handler
+	getBreaker     grpc.Handler
+	forceOpen      grpc.Handler
+	forceClose     grpc.Handler
+	reset          grpc.Handler
+	disable        grpc.Handler
+	setMetricsOnly grpc.Handler
+	listRequirings grpc.Handler
+	exportGraph    grpc.Handler
+	protobuf.UnimplementedCircuitBreakerAdminServer
+}
+
+func NewAdminServer(ep endpoint.AdminEndpoint) protobuf.CircuitBreakerAdminServer {
+	opts := []grpc.ServerOption{}
+
+	return &adminHandler{
+		listBreakers: grpc.NewServer(
+			ep.ListBreakersEp,
+			decodeListBreakersRequest,
+			encodeListBreakersResponse,
+			opts...,
+		),
+		getBreaker: grpc.NewServer(
+			ep.GetBreakerEp,
+			decodeBreakerRequest,
+			encodeBreakerResponse,
+			opts...,
+		),
+		forceOpen: grpc.NewServer(
+			ep.ForceOpenEp,
+			decodeBreakerRequest,
+			encodeBreakerResponse,
+			opts...,
+		),
+		forceClose: grpc.NewServer(
+			ep.ForceCloseEp,
+			decodeBreakerRequest,
+			encodeBreakerResponse,
+			opts...,
+		),
+		reset: grpc.NewServer(
+			ep.ResetEp,
+			decodeBreakerRequest,
+			encodeBreakerResponse,
+			opts...,
+		),
+		disable: grpc.NewServer(
+			ep.DisableEp,
+			decodeBreakerRequest,
+			encodeBreakerResponse,
+			opts...,
+		),
+		setMetricsOnly: grpc.NewServer(
+			ep.SetMetricsOnlyEp,
+			decodeSetMetricsOnlyRequest,
+			encodeBreakerResponse,
+			opts...,
+		),
+		listRequirings: grpc.NewServer(
+			ep.ListRequiringsEp,
+			decodeBreakerRequest,
+			encodeListRequiringsResponse,
+			opts...,
+		),
+		exportGraph: grpc.NewServer(
+			ep.ExportGraphEp,
+			decodeExportGraphRequest,
+			encodeExportGraphResponse,
+			opts...,
+		),
+	}
+}
+
+func (h *adminHandler) ListBreakers(ctx context.Context, req *emptypb.Empty) (*protobuf.ListBreakersResponse, error) {
+	_, res, err := h.listBreakers.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*protobuf.ListBreakersResponse), nil
+}
+
+func (h *adminHandler) GetBreaker(ctx context.Context, req *protobuf.BreakerRequest) (*protobuf.Breaker, error) {
+	_, res, err := h.getBreaker.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*protobuf.Breaker), nil
+}
+
+func (h *adminHandler) ForceOpen(ctx context.Context, req *protobuf.BreakerRequest) (*protobuf.Breaker, error) {
+	_, res, err := h.forceOpen.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*protobuf.Breaker), nil
+}
+
+func (h *adminHandler) ForceClose(ctx context.Context, req *protobuf.BreakerRequest) (*protobuf.Breaker, error) {
+	_, res, err := h.forceClose.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*protobuf.Breaker), nil
+}
+
+func (h *adminHandler) Reset(ctx context.Context, req *protobuf.BreakerRequest) (*protobuf.Breaker, error) {
+	_, res, err := h.reset.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*protobuf.Breaker), nil
+}
+
+func (h *adminHandler) Disable(ctx context.Context, req *protobuf.BreakerRequest) (*protobuf.Breaker, error) {
+	_, res, err := h.disable.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*protobuf.Breaker), nil
+}
+
+func (h *adminHandler) SetMetricsOnly(ctx context.Context, req *protobuf.SetMetricsOnlyRequest) (*protobuf.Breaker, error) {
+	_, res, err := h.setMetricsOnly.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*protobuf.Breaker), nil
+}
+
+func (h *adminHandler) ListRequirings(ctx context.Context, req *protobuf.BreakerRequest) (*protobuf.ListRequiringsResponse, error) {
+	_, res, err := h.listRequirings.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*protobuf.ListRequiringsResponse), nil
+}
+
+func (h *adminHandler) ExportGraph(ctx context.Context, req *protobuf.ExportGraphRequest) (*protobuf.ExportGraphResponse, error) {
+	_, res, err := h.exportGraph.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*protobuf.ExportGraphResponse), nil
+}
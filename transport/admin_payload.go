@@ -0,0 +1,93 @@
+package transport
+
+import (
+	"context"
+
+	"github.com/daffarg/distributed-cascading-cb/protobuf"
+	"github.com/daffarg/distributed-cascading-cb/service"
+)
+
+func decodeListBreakersRequest(_ context.Context, _ interface{}) (interface{}, error) {
+	return &service.ListBreakersRequest{}, nil
+}
+
+func decodeBreakerRequest(_ context.Context, r interface{}) (interface{}, error) {
+	pbReq := r.(*protobuf.BreakerRequest)
+
+	return &service.BreakerRequest{
+		Endpoint: pbReq.Endpoint,
+		Method:   pbReq.Method,
+	}, nil
+}
+
+func decodeSetMetricsOnlyRequest(_ context.Context, r interface{}) (interface{}, error) {
+	pbReq := r.(*protobuf.SetMetricsOnlyRequest)
+
+	return &service.SetMetricsOnlyRequest{
+		Endpoint: pbReq.Endpoint,
+		Method:   pbReq.Method,
+		Enabled:  pbReq.Enabled,
+	}, nil
+}
+
+func decodeExportGraphRequest(_ context.Context, r interface{}) (interface{}, error) {
+	pbReq := r.(*protobuf.ExportGraphRequest)
+
+	return &service.ExportGraphRequest{
+		Format: pbReq.Format,
+	}, nil
+}
+
+func encodeListBreakersResponse(_ context.Context, r interface{}) (interface{}, error) {
+	res := r.(*service.ListBreakersResponse)
+
+	breakers := make([]*protobuf.Breaker, 0, len(res.Breakers))
+	for _, breaker := range res.Breakers {
+		breakers = append(breakers, convertBreaker(breaker))
+	}
+
+	return &protobuf.ListBreakersResponse{
+		Breakers: breakers,
+	}, nil
+}
+
+func encodeBreakerResponse(_ context.Context, r interface{}) (interface{}, error) {
+	return convertBreaker(r.(*service.Breaker)), nil
+}
+
+func encodeListRequiringsResponse(_ context.Context, r interface{}) (interface{}, error) {
+	res := r.(*service.ListRequiringsResponse)
+
+	return &protobuf.ListRequiringsResponse{
+		Name:       res.Name,
+		Requirings: res.Requirings,
+	}, nil
+}
+
+func encodeExportGraphResponse(_ context.Context, r interface{}) (interface{}, error) {
+	res := r.(*service.ExportGraphResponse)
+
+	return &protobuf.ExportGraphResponse{
+		Format: res.Format,
+		Graph:  res.Graph,
+	}, nil
+}
+
+func convertBreaker(breaker *service.Breaker) *protobuf.Breaker {
+	return &protobuf.Breaker{
+		Name:                 breaker.Name,
+		State:                breaker.State,
+		StoredStatus:         breaker.StoredStatus,
+		IsLocal:              breaker.IsLocal,
+		Requests:             breaker.Requests,
+		TotalSuccesses:       breaker.TotalSuccesses,
+		TotalFailures:        breaker.TotalFailures,
+		ConsecutiveSuccesses: breaker.ConsecutiveSuccesses,
+		ConsecutiveFailures:  breaker.ConsecutiveFailures,
+		WindowRequests:       breaker.WindowRequests,
+		WindowFailures:       breaker.WindowFailures,
+		WindowSlowCalls:      breaker.WindowSlowCalls,
+		OpenTimeout:          breaker.OpenTimeout,
+		MetricsOnly:          breaker.MetricsOnly,
+	}
+}
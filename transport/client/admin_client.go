@@ -0,0 +1,143 @@
+package client
+
+import (
+	cbEndpoint "github.com/daffarg/distributed-cascading-cb/endpoint"
+	"github.com/daffarg/distributed-cascading-cb/protobuf"
+	"github.com/daffarg/distributed-cascading-cb/service"
+	"github.com/go-kit/kit/endpoint"
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/grpc"
+)
+
+func NewGRPCAdminClient(conn *grpc.ClientConn) service.AdminService {
+	var options []grpctransport.ClientOption
+
+	var listBreakersEndpoint endpoint.Endpoint
+	{
+		listBreakersEndpoint = grpctransport.NewClient(
+			conn,
+			"protobuf.CircuitBreakerAdmin",
+			"ListBreakers",
+			encodeListBreakersRequest,
+			decodeListBreakersResponse,
+			protobuf.ListBreakersResponse{},
+			options...,
+		).Endpoint()
+	}
+
+	var getBreakerEndpoint endpoint.Endpoint
+	{
+		getBreakerEndpoint = grpctransport.NewClient(
+			conn,
+			"protobuf.CircuitBreakerAdmin",
+			"GetBreaker",
+			encodeBreakerRequest,
+			decodeBreakerResponse,
+			protobuf.Breaker{},
+			options...,
+		).Endpoint()
+	}
+
+	var forceOpenEndpoint endpoint.Endpoint
+	{
+		forceOpenEndpoint = grpctransport.NewClient(
+			conn,
+			"protobuf.CircuitBreakerAdmin",
+			"ForceOpen",
+			encodeBreakerRequest,
+			decodeBreakerResponse,
+			protobuf.Breaker{},
+			options...,
+		).Endpoint()
+	}
+
+	var forceCloseEndpoint endpoint.Endpoint
+	{
+		forceCloseEndpoint = grpctransport.NewClient(
+			conn,
+			"protobuf.CircuitBreakerAdmin",
+			"ForceClose",
+			encodeBreakerRequest,
+			decodeBreakerResponse,
+			protobuf.Breaker{},
+			options...,
+		).Endpoint()
+	}
+
+	var resetEndpoint endpoint.Endpoint
+	{
+		resetEndpoint = grpctransport.NewClient(
+			conn,
+			"protobuf.CircuitBreakerAdmin",
+			"Reset",
+			encodeBreakerRequest,
+			decodeBreakerResponse,
+			protobuf.Breaker{},
+			options...,
+		).Endpoint()
+	}
+
+	var disableEndpoint endpoint.Endpoint
+	{
+		disableEndpoint = grpctransport.NewClient(
+			conn,
+			"protobuf.CircuitBreakerAdmin",
+			"Disable",
+			encodeBreakerRequest,
+			decodeBreakerResponse,
+			protobuf.Breaker{},
+			options...,
+		).Endpoint()
+	}
+
+	var setMetricsOnlyEndpoint endpoint.Endpoint
+	{
+		setMetricsOnlyEndpoint = grpctransport.NewClient(
+			conn,
+			"protobuf.CircuitBreakerAdmin",
+			"SetMetricsOnly",
+			encodeSetMetricsOnlyRequest,
+			decodeBreakerResponse,
+			protobuf.Breaker{},
+			options...,
+		).Endpoint()
+	}
+
+	var listRequiringsEndpoint endpoint.Endpoint
+	{
+		listRequiringsEndpoint = grpctransport.NewClient(
+			conn,
+			"protobuf.CircuitBreakerAdmin",
+			"ListRequirings",
+			encodeBreakerRequest,
+			decodeListRequiringsResponse,
+			protobuf.ListRequiringsResponse{},
+			options...,
+		).Endpoint()
+	}
+
+	var exportGraphEndpoint endpoint.Endpoint
+	{
+		exportGraphEndpoint = grpctransport.NewClient(
+			conn,
+			"protobuf.CircuitBreakerAdmin",
+			"ExportGraph",
+			encodeExportGraphRequest,
+			decodeExportGraphResponse,
+			protobuf.ExportGraphResponse{},
+			options...,
+		).Endpoint()
+	}
+
+	return &cbEndpoint.AdminEndpoint{
+		ListBreakersEp:   listBreakersEndpoint,
+		GetBreakerEp:     getBreakerEndpoint,
+		ForceOpenEp:      forceOpenEndpoint,
+		ForceCloseEp:     forceCloseEndpoint,
+		ResetEp:          resetEndpoint,
+		DisableEp:        disableEndpoint,
+		SetMetricsOnlyEp: setMetricsOnlyEndpoint,
+		ListRequiringsEp: listRequiringsEndpoint,
+		ExportGraphEp:    exportGraphEndpoint,
+	}
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"github.com/daffarg/distributed-cascading-cb/protobuf"
+	"github.com/daffarg/distributed-cascading-cb/service"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func encodeListBreakersRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	return &emptypb.Empty{}, nil
+}
+
+func encodeBreakerRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*service.BreakerRequest)
+	return &protobuf.BreakerRequest{
+		Endpoint: req.Endpoint,
+		Method:   req.Method,
+	}, nil
+}
+
+func encodeSetMetricsOnlyRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*service.SetMetricsOnlyRequest)
+	return &protobuf.SetMetricsOnlyRequest{
+		Endpoint: req.Endpoint,
+		Method:   req.Method,
+		Enabled:  req.Enabled,
+	}, nil
+}
+
+func decodeListBreakersResponse(ctx context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*protobuf.ListBreakersResponse)
+	breakers := make([]*service.Breaker, 0, len(res.Breakers))
+	for _, breaker := range res.Breakers {
+		breakers = append(breakers, convertBreaker(breaker))
+	}
+	return &service.ListBreakersResponse{
+		Breakers: breakers,
+	}, nil
+}
+
+func decodeBreakerResponse(ctx context.Context, grpcRes interface{}) (interface{}, error) {
+	return convertBreaker(grpcRes.(*protobuf.Breaker)), nil
+}
+
+func decodeListRequiringsResponse(ctx context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*protobuf.ListRequiringsResponse)
+	return &service.ListRequiringsResponse{
+		Name:       res.Name,
+		Requirings: res.Requirings,
+	}, nil
+}
+
+func encodeExportGraphRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*service.ExportGraphRequest)
+	return &protobuf.ExportGraphRequest{
+		Format: req.Format,
+	}, nil
+}
+
+func decodeExportGraphResponse(ctx context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*protobuf.ExportGraphResponse)
+	return &service.ExportGraphResponse{
+		Format: res.Format,
+		Graph:  res.Graph,
+	}, nil
+}
+
+func convertBreaker(breaker *protobuf.Breaker) *service.Breaker {
+	return &service.Breaker{
+		Name:                 breaker.Name,
+		State:                breaker.State,
+		StoredStatus:         breaker.StoredStatus,
+		IsLocal:              breaker.IsLocal,
+		Requests:             breaker.Requests,
+		TotalSuccesses:       breaker.TotalSuccesses,
+		TotalFailures:        breaker.TotalFailures,
+		ConsecutiveSuccesses: breaker.ConsecutiveSuccesses,
+		ConsecutiveFailures:  breaker.ConsecutiveFailures,
+		WindowRequests:       breaker.WindowRequests,
+		WindowFailures:       breaker.WindowFailures,
+		WindowSlowCalls:      breaker.WindowSlowCalls,
+		OpenTimeout:          breaker.OpenTimeout,
+		MetricsOnly:          breaker.MetricsOnly,
+	}
+}
@@ -1,12 +1,17 @@
 package client
 
 import (
+	"context"
 	cbEndpoint "github.com/daffarg/distributed-cascading-cb/endpoint"
 	"github.com/daffarg/distributed-cascading-cb/protobuf"
 	"github.com/daffarg/distributed-cascading-cb/service"
+	"github.com/daffarg/distributed-cascading-cb/util"
 	"github.com/go-kit/kit/endpoint"
 	grpctransport "github.com/go-kit/kit/transport/grpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
 )
 
 func NewGRPCClient(conn *grpc.ClientConn) service.CircuitBreakerService {
@@ -77,11 +82,102 @@ func NewGRPCClient(conn *grpc.ClientConn) service.CircuitBreakerService {
 		).Endpoint()
 	}
 
+	// go-kit only supports unary RPCs, so the streams are read through the generated client
+	var generalStreamEndpoint endpoint.Endpoint
+	{
+		generalStreamEndpoint = makeGeneralStreamEndpoint(protobuf.NewCircuitBreakerClient(conn))
+	}
+
+	var watchStatusEndpoint endpoint.Endpoint
+	{
+		watchStatusEndpoint = makeWatchStatusEndpoint(protobuf.NewCircuitBreakerClient(conn))
+	}
+
 	return &cbEndpoint.CircuitBreakerEndpoint{
-		GeneralEp: generalEndpoint,
-		GetEp:     getEndpoint,
-		PostEp:    postEndpoint,
-		PutEp:     putEndpoint,
-		DeleteEp:  deleteEndpoint,
+		GeneralEp:       generalEndpoint,
+		GetEp:           getEndpoint,
+		PostEp:          postEndpoint,
+		PutEp:           putEndpoint,
+		DeleteEp:        deleteEndpoint,
+		GeneralStreamEp: generalStreamEndpoint,
+		WatchStatusEp:   watchStatusEndpoint,
+	}
+}
+
+// makeGeneralStreamEndpoint sends the request through GeneralBidiStream, the body of the response is read from the
+// stream while the caller reads it and closing it ends the stream
+func makeGeneralStreamEndpoint(client protobuf.CircuitBreakerClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*service.StreamRequest)
+
+		ctx, cancel := context.WithCancel(ctx)
+		stream, err := client.GeneralBidiStream(ctx)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+
+		if err := stream.Send(encodeStreamRequestHeader(req)); err != nil {
+			cancel()
+			return nil, err
+		}
+		go sendStreamBody(stream, req.Body)
+
+		msg, err := stream.Recv()
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		header := msg.GetHeader()
+		if header == nil {
+			cancel()
+			return nil, status.Error(codes.Internal, util.ErrMissingStreamHeader.Error())
+		}
+
+		resp, err := decodeResponse(ctx, header)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		res := resp.(*service.Response)
+
+		body, writer := io.Pipe()
+		res.BodyStream = &streamBody{PipeReader: body, cancel: cancel}
+		go receiveStreamBody(stream, res, writer)
+
+		return res, nil
+	}
+}
+
+func makeWatchStatusEndpoint(client protobuf.CircuitBreakerClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := encodeWatchStatusRequest(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		stream, err := client.WatchStatus(ctx, req.(*protobuf.WatchStatusRequest))
+		if err != nil {
+			return nil, err
+		}
+
+		statuses := make(chan *protobuf.Status)
+		go func() {
+			defer close(statuses)
+			for {
+				msg, err := stream.Recv()
+				if err != nil {
+					return
+				}
+
+				select {
+				case statuses <- msg:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		return (<-chan *protobuf.Status)(statuses), nil
 	}
 }
@@ -2,8 +2,12 @@ package client
 
 import (
 	"context"
+	"errors"
 	"github.com/daffarg/distributed-cascading-cb/protobuf"
 	"github.com/daffarg/distributed-cascading-cb/service"
+	"github.com/daffarg/distributed-cascading-cb/util"
+	"io"
+	"net/http"
 )
 
 func encodeGeneralRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
@@ -63,13 +67,109 @@ func encodeDeleteRequest(ctx context.Context, grpcReq interface{}) (interface{},
 func decodeResponse(ctx context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(*protobuf.Response)
 	return &service.Response{
-		Status:        res.Status,
-		StatusCode:    res.StatusCode,
-		Proto:         res.Proto,
-		ProtoMajor:    res.ProtoMajor,
-		ProtoMinor:    res.ProtoMinor,
-		Header:        res.Header,
-		Body:          res.Body,
-		ContentLength: res.ContentLength,
+		Status:                    res.Status,
+		StatusCode:                res.StatusCode,
+		Proto:                     res.Proto,
+		ProtoMajor:                res.ProtoMajor,
+		ProtoMinor:                res.ProtoMinor,
+		Header:                    res.Header,
+		Body:                      res.Body,
+		ContentLength:             res.ContentLength,
+		IsFromAlternativeEndpoint: res.IsFromAlternativeEndpoint,
+		IsFailure:                 res.IsFailure,
+		Headers:                   decodeHeaderValues(res.Headers),
+		Trailers:                  decodeHeaderValues(res.Trailers),
 	}, nil
 }
+
+func decodeHeaderValues(headerValues map[string]*protobuf.HeaderValues) http.Header {
+	if headerValues == nil {
+		return nil
+	}
+
+	header := make(http.Header, len(headerValues))
+	for k, v := range headerValues {
+		header[k] = v.GetValues()
+	}
+	return header
+}
+
+func encodeWatchStatusRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*service.WatchStatusRequest)
+	endpoints := make([]*protobuf.BreakerRequest, 0, len(req.Endpoints))
+	for _, ep := range req.Endpoints {
+		endpoints = append(endpoints, &protobuf.BreakerRequest{
+			Endpoint: ep.Endpoint,
+			Method:   ep.Method,
+		})
+	}
+	return &protobuf.WatchStatusRequest{
+		Endpoints: endpoints,
+	}, nil
+}
+
+func encodeStreamRequestHeader(req *service.StreamRequest) *protobuf.StreamRequest {
+	return &protobuf.StreamRequest{Part: &protobuf.StreamRequest_Header{Header: &protobuf.GeneralRequest{
+		Method:            req.Method,
+		Url:               req.URL,
+		Header:            req.Header,
+		RequiringEndpoint: req.RequiringEndpoint,
+		RequiringMethod:   req.RequiringMethod,
+	}}}
+}
+
+// sendStreamBody sends the body in chunks and closes the sending side of the stream, a failed send is reported by
+// the receiving side
+func sendStreamBody(stream protobuf.CircuitBreaker_GeneralBidiStreamClient, body io.Reader) {
+	defer stream.CloseSend()
+	if body == nil {
+		return
+	}
+
+	chunk := make([]byte, util.StreamChunkSize)
+	for {
+		n, err := body.Read(chunk)
+		if n > 0 {
+			if err := stream.Send(&protobuf.StreamRequest{Part: &protobuf.StreamRequest_Chunk{Chunk: chunk[:n]}}); err != nil {
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// receiveStreamBody writes the chunks of the response into its body and sets its trailers before the body ends
+func receiveStreamBody(stream protobuf.CircuitBreaker_GeneralBidiStreamClient, res *service.Response, body *io.PipeWriter) {
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+			body.CloseWithError(err)
+			return
+		}
+
+		switch part := msg.Part.(type) {
+		case *protobuf.StreamResponse_Chunk:
+			if _, err := body.Write(part.Chunk); err != nil {
+				return
+			}
+		case *protobuf.StreamResponse_Trailers:
+			res.Trailers = decodeHeaderValues(part.Trailers.GetTrailers())
+		}
+	}
+}
+
+// streamBody is the body of a streamed response, closing it cancels the stream
+type streamBody struct {
+	*io.PipeReader
+	cancel context.CancelFunc
+}
+
+func (b *streamBody) Close() error {
+	b.cancel()
+	return b.PipeReader.Close()
+}
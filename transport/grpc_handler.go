@@ -5,6 +5,8 @@ import (
 
 	"github.com/daffarg/distributed-cascading-cb/endpoint"
 	"github.com/daffarg/distributed-cascading-cb/protobuf"
+	"github.com/daffarg/distributed-cascading-cb/service"
+	kitendpoint "github.com/go-kit/kit/endpoint"
 	"github.com/go-kit/kit/transport/grpc"
 )
 
@@ -14,6 +16,9 @@ type handler struct {
 	post    grpc.Handler
 	put     grpc.Handler
 	delete  grpc.Handler
+	// generalStream and watchStatus are served without a grpc.Handler since go-kit only supports unary RPCs
+	generalStream kitendpoint.Endpoint
+	watchStatus   kitendpoint.Endpoint
 	protobuf.UnimplementedCircuitBreakerServer
 }
 
@@ -51,6 +56,8 @@ func NewCircuitBreakerServer(ep endpoint.CircuitBreakerEndpoint) protobuf.Circui
 			encodeResponse,
 			opts...,
 		),
+		generalStream: ep.GeneralStreamEp,
+		watchStatus:   ep.WatchStatusEp,
 	}
 }
 
@@ -93,3 +100,79 @@ func (h *handler) Delete(ctx context.Context, req *protobuf.DeleteRequest) (*pro
 	}
 	return res.(*protobuf.Response), nil
 }
+
+// GeneralClientStream streams the body of the request and buffers the body of the response
+func (h *handler) GeneralClientStream(stream protobuf.CircuitBreaker_GeneralClientStreamServer) error {
+	ctx := stream.Context()
+
+	request, err := decodeStreamRequest(stream.Recv)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.generalStream(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	pbRes, err := encodeBufferedStreamResponse(ctx, res.(*service.Response))
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(pbRes)
+}
+
+// GeneralServerStream buffers the body of the request and streams the body of the response
+func (h *handler) GeneralServerStream(req *protobuf.GeneralRequest, stream protobuf.CircuitBreaker_GeneralServerStreamServer) error {
+	ctx := stream.Context()
+
+	request, err := decodeGeneralStreamRequest(req, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.generalStream(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	return encodeStreamResponse(ctx, res.(*service.Response), stream.Send)
+}
+
+// GeneralBidiStream streams the bodies of both the request and the response
+func (h *handler) GeneralBidiStream(stream protobuf.CircuitBreaker_GeneralBidiStreamServer) error {
+	ctx := stream.Context()
+
+	request, err := decodeStreamRequest(stream.Recv)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.generalStream(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	return encodeStreamResponse(ctx, res.(*service.Response), stream.Send)
+}
+
+func (h *handler) WatchStatus(req *protobuf.WatchStatusRequest, stream protobuf.CircuitBreaker_WatchStatusServer) error {
+	ctx := stream.Context()
+
+	request, err := decodeWatchStatusRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.watchStatus(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	for msg := range res.(<-chan *protobuf.Status) {
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
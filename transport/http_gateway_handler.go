@@ -0,0 +1,36 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/daffarg/distributed-cascading-cb/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+)
+
+// NewHTTPGatewayServer serves the CircuitBreaker and CircuitBreakerAdmin RPCs as JSON over HTTP for the callers
+// that cannot use gRPC. The request RPCs also take and return raw bodies with the body=raw query parameter.
+func NewHTTPGatewayServer(ep endpoint.CircuitBreakerEndpoint, adminEp endpoint.AdminEndpoint) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerBefore(withBodyMode),
+		kithttp.ServerErrorEncoder(encodeGatewayError),
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("POST /v1/general", kithttp.NewServer(ep.GeneralEp, decodeGatewayGeneralRequest, encodeGatewayResponse, opts...))
+	mux.Handle("POST /v1/get", kithttp.NewServer(ep.GetEp, decodeGatewayGetRequest, encodeGatewayResponse, opts...))
+	mux.Handle("POST /v1/post", kithttp.NewServer(ep.PostEp, decodeGatewayPostRequest, encodeGatewayResponse, opts...))
+	mux.Handle("POST /v1/put", kithttp.NewServer(ep.PutEp, decodeGatewayPutRequest, encodeGatewayResponse, opts...))
+	mux.Handle("POST /v1/delete", kithttp.NewServer(ep.DeleteEp, decodeGatewayDeleteRequest, encodeGatewayResponse, opts...))
+
+	mux.Handle("GET /v1/admin/breakers", kithttp.NewServer(adminEp.ListBreakersEp, decodeGatewayListBreakersRequest, encodeGatewayResponse, opts...))
+	mux.Handle("GET /v1/admin/breaker", kithttp.NewServer(adminEp.GetBreakerEp, decodeGatewayBreakerQuery, encodeGatewayResponse, opts...))
+	mux.Handle("POST /v1/admin/breaker/force-open", kithttp.NewServer(adminEp.ForceOpenEp, decodeGatewayBreakerRequest, encodeGatewayResponse, opts...))
+	mux.Handle("POST /v1/admin/breaker/force-close", kithttp.NewServer(adminEp.ForceCloseEp, decodeGatewayBreakerRequest, encodeGatewayResponse, opts...))
+	mux.Handle("POST /v1/admin/breaker/reset", kithttp.NewServer(adminEp.ResetEp, decodeGatewayBreakerRequest, encodeGatewayResponse, opts...))
+	mux.Handle("POST /v1/admin/breaker/disable", kithttp.NewServer(adminEp.DisableEp, decodeGatewayBreakerRequest, encodeGatewayResponse, opts...))
+	mux.Handle("POST /v1/admin/breaker/metrics-only", kithttp.NewServer(adminEp.SetMetricsOnlyEp, decodeGatewaySetMetricsOnlyRequest, encodeGatewayResponse, opts...))
+	mux.Handle("GET /v1/admin/requirings", kithttp.NewServer(adminEp.ListRequiringsEp, decodeGatewayBreakerQuery, encodeGatewayResponse, opts...))
+	mux.Handle("GET /v1/admin/graph", kithttp.NewServer(adminEp.ExportGraphEp, decodeGatewayExportGraphRequest, encodeGatewayResponse, opts...))
+
+	return mux
+}
@@ -0,0 +1,109 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/daffarg/distributed-cascading-cb/endpoint"
+	"github.com/daffarg/distributed-cascading-cb/service"
+	"github.com/daffarg/distributed-cascading-cb/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewHTTPGatewayServer(t *testing.T) {
+	t.Setenv("CB_MAX_BUFFERED_BODY_SIZE", "16")
+
+	var gotRequest *service.PostRequest
+	ep := endpoint.CircuitBreakerEndpoint{
+		PostEp: func(_ context.Context, request interface{}) (interface{}, error) {
+			gotRequest = request.(*service.PostRequest)
+			if gotRequest.URL == "http://localhost:8081/open" {
+				return nil, status.Error(codes.Unavailable, util.ErrCircuitBreakerOpen.Error())
+			}
+			return &service.Response{
+				StatusCode: http.StatusCreated,
+				Header:     map[string]string{"Content-Type": "text/plain"},
+				Body:       []byte("created"),
+			}, nil
+		},
+	}
+	handler := NewHTTPGatewayServer(ep, endpoint.AdminEndpoint{})
+
+	tests := []struct {
+		name        string
+		target      string
+		body        string
+		wantRequest *service.PostRequest
+		wantStatus  int
+		wantBody    string
+	}{
+		{
+			name:   "Base64_body",
+			target: "/v1/post",
+			body:   `{"url":"http://localhost:8081/hello","body":"aGVsbG8=","requiring_endpoint":"http://localhost:8080/hello","requiring_method":"GET"}`,
+			wantRequest: &service.PostRequest{
+				URL:               "http://localhost:8081/hello",
+				Body:              []byte("hello"),
+				RequiringEndpoint: "http://localhost:8080/hello",
+				RequiringMethod:   "GET",
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `"body":"Y3JlYXRlZA=="`,
+		},
+		{
+			name:   "Raw_body",
+			target: "/v1/post?body=raw&url=http://localhost:8081/hello&requiring_endpoint=http://localhost:8080/hello&requiring_method=GET&header=X-Id:%201",
+			body:   "hello",
+			wantRequest: &service.PostRequest{
+				URL:               "http://localhost:8081/hello",
+				Header:            map[string]string{"X-Id": "1"},
+				Body:              []byte("hello"),
+				RequiringEndpoint: "http://localhost:8080/hello",
+				RequiringMethod:   "GET",
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   "created",
+		},
+		{
+			name:   "Circuit_breaker_open",
+			target: "/v1/post",
+			body:   `{"url":"http://localhost:8081/open","requiring_endpoint":"http://localhost:8080/hello","requiring_method":"GET"}`,
+			wantRequest: &service.PostRequest{
+				URL:               "http://localhost:8081/open",
+				RequiringEndpoint: "http://localhost:8080/hello",
+				RequiringMethod:   "GET",
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   `"code":"Unavailable"`,
+		},
+		{
+			name:       "Raw_body_too_large",
+			target:     "/v1/post?body=raw&url=http://localhost:8081/hello&requiring_endpoint=http://localhost:8080/hello&requiring_method=GET",
+			body:       strings.Repeat("a", 17),
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantBody:   `"code":"ResourceExhausted"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRequest = nil
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(tt.body)))
+
+			if !reflect.DeepEqual(gotRequest, tt.wantRequest) {
+				t.Errorf("request = %+v, want %+v", gotRequest, tt.wantRequest)
+			}
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("body = %v, want it to contain %v", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
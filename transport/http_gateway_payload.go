@@ -0,0 +1,255 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+
+	"github.com/daffarg/distributed-cascading-cb/service"
+	"github.com/daffarg/distributed-cascading-cb/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	bodyModeBase64 = "base64"
+	bodyModeRaw    = "raw"
+)
+
+type bodyModeKey struct{}
+
+// withBodyMode keeps the body mode of the request for its response. By default the requests and responses are
+// JSON with base64 bodies, with body=raw the request body is sent as is, its fields are given as query parameters,
+// and the response is the upstream response itself.
+func withBodyMode(ctx context.Context, r *http.Request) context.Context {
+	mode := r.URL.Query().Get("body")
+	if mode != bodyModeRaw {
+		mode = bodyModeBase64
+	}
+	return context.WithValue(ctx, bodyModeKey{}, mode)
+}
+
+func isRawBody(ctx context.Context) bool {
+	mode, _ := ctx.Value(bodyModeKey{}).(string)
+	return mode == bodyModeRaw
+}
+
+type gatewayError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func decodeGatewayGeneralRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	req := &service.GeneralRequest{}
+	if !isRawBody(ctx) {
+		return req, decodeGatewayJSON(r, req)
+	}
+
+	query := r.URL.Query()
+	body, err := util.ReadAllLimited(r.Body, util.GetMaxBufferedBodySize())
+	if err != nil {
+		return nil, err
+	}
+
+	req.Method = query.Get("method")
+	req.URL = query.Get("url")
+	req.Header = decodeGatewayHeader(r, query)
+	req.Body = body
+	req.RequiringEndpoint = query.Get("requiring_endpoint")
+	req.RequiringMethod = query.Get("requiring_method")
+	return req, nil
+}
+
+func decodeGatewayGetRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	req := &service.GetRequest{}
+	if !isRawBody(ctx) {
+		return req, decodeGatewayJSON(r, req)
+	}
+
+	query := r.URL.Query()
+	req.URL = query.Get("url")
+	req.Header = decodeGatewayHeader(r, query)
+	req.RequiringEndpoint = query.Get("requiring_endpoint")
+	req.RequiringMethod = query.Get("requiring_method")
+	return req, nil
+}
+
+func decodeGatewayPostRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	req := &service.PostRequest{}
+	if !isRawBody(ctx) {
+		return req, decodeGatewayJSON(r, req)
+	}
+
+	query := r.URL.Query()
+	body, err := util.ReadAllLimited(r.Body, util.GetMaxBufferedBodySize())
+	if err != nil {
+		return nil, err
+	}
+
+	req.URL = query.Get("url")
+	req.Header = decodeGatewayHeader(r, query)
+	req.Body = body
+	req.RequiringEndpoint = query.Get("requiring_endpoint")
+	req.RequiringMethod = query.Get("requiring_method")
+	return req, nil
+}
+
+func decodeGatewayPutRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	req := &service.PutRequest{}
+	if !isRawBody(ctx) {
+		return req, decodeGatewayJSON(r, req)
+	}
+
+	query := r.URL.Query()
+	body, err := util.ReadAllLimited(r.Body, util.GetMaxBufferedBodySize())
+	if err != nil {
+		return nil, err
+	}
+
+	req.URL = query.Get("url")
+	req.Header = decodeGatewayHeader(r, query)
+	req.Body = body
+	req.RequiringEndpoint = query.Get("requiring_endpoint")
+	req.RequiringMethod = query.Get("requiring_method")
+	return req, nil
+}
+
+func decodeGatewayDeleteRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	req := &service.DeleteRequest{}
+	if !isRawBody(ctx) {
+		return req, decodeGatewayJSON(r, req)
+	}
+
+	query := r.URL.Query()
+	req.URL = query.Get("url")
+	req.Header = decodeGatewayHeader(r, query)
+	req.RequiringEndpoint = query.Get("requiring_endpoint")
+	req.RequiringMethod = query.Get("requiring_method")
+	return req, nil
+}
+
+func decodeGatewayListBreakersRequest(_ context.Context, _ *http.Request) (interface{}, error) {
+	return &service.ListBreakersRequest{}, nil
+}
+
+func decodeGatewayBreakerQuery(_ context.Context, r *http.Request) (interface{}, error) {
+	query := r.URL.Query()
+	return &service.BreakerRequest{
+		Endpoint: query.Get("endpoint"),
+		Method:   query.Get("method"),
+	}, nil
+}
+
+func decodeGatewayBreakerRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := &service.BreakerRequest{}
+	return req, decodeGatewayJSON(r, req)
+}
+
+func decodeGatewaySetMetricsOnlyRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := &service.SetMetricsOnlyRequest{}
+	return req, decodeGatewayJSON(r, req)
+}
+
+func decodeGatewayExportGraphRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return &service.ExportGraphRequest{
+		Format: r.URL.Query().Get("format"),
+	}, nil
+}
+
+// decodeGatewayJSON decodes the JSON request, its size is limited to the base64 encoding of the largest buffered body
+// with the same margin for the other fields as the gRPC messages
+func decodeGatewayJSON(r *http.Request, req interface{}) error {
+	body, err := util.ReadAllLimited(r.Body, (util.GetMaxBufferedBodySize()+2)/3*4+1<<20)
+	if err != nil {
+		return err
+	}
+	if len(body) == 0 {
+		return nil
+	}
+
+	if err = json.Unmarshal(body, req); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// decodeGatewayHeader reads the headers of a raw body request from its "Name: Value" header query parameters,
+// the content type of the request is used when they do not set one
+func decodeGatewayHeader(r *http.Request, query url.Values) map[string]string {
+	header := make(map[string]string)
+	for _, field := range query["header"] {
+		name, value, ok := strings.Cut(field, ":")
+		if !ok {
+			continue
+		}
+		header[textproto.CanonicalMIMEHeaderKey(strings.TrimSpace(name))] = strings.TrimSpace(value)
+	}
+
+	if _, ok := header["Content-Type"]; !ok && r.Header.Get("Content-Type") != "" {
+		header["Content-Type"] = r.Header.Get("Content-Type")
+	}
+
+	return header
+}
+
+func encodeGatewayResponse(ctx context.Context, w http.ResponseWriter, r interface{}) error {
+	if res, ok := r.(*service.Response); ok && isRawBody(ctx) {
+		return encodeProxyResponse(ctx, w, res)
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(r)
+}
+
+func encodeGatewayError(_ context.Context, err error, w http.ResponseWriter) {
+	if errors.Is(err, util.ErrBodyTooLarge) {
+		err = status.Error(codes.ResourceExhausted, err.Error())
+	}
+	st := status.Convert(err)
+
+	statusCode := httpStatusFromCode(st.Code())
+	if st.Code() == codes.ResourceExhausted && st.Message() == util.ErrBodyTooLarge.Error() {
+		statusCode = http.StatusRequestEntityTooLarge
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(gatewayError{
+		Code:    st.Code().String(),
+		Message: st.Message(),
+	})
+}
+
+// httpStatusFromCode maps a gRPC code to its HTTP status, following the mapping of google.rpc.Code
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
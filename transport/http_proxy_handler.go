@@ -0,0 +1,24 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/daffarg/distributed-cascading-cb/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+)
+
+// NewHTTPProxyServer serves plain HTTP requests through the GeneralStream endpoint, both as a forward proxy
+// for clients setting HTTP_PROXY and as a reverse proxy routing the requests by their Host header.
+// The request and response bodies are streamed, so their size is not limited.
+func NewHTTPProxyServer(ep endpoint.CircuitBreakerEndpoint) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(encodeProxyError),
+	}
+
+	return kithttp.NewServer(
+		ep.GeneralStreamEp,
+		decodeProxyRequest,
+		encodeProxyResponse,
+		opts...,
+	)
+}
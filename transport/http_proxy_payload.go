@@ -0,0 +1,121 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/daffarg/distributed-cascading-cb/service"
+	"github.com/daffarg/distributed-cascading-cb/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	headerRequiringEndpoint   = "X-Cb-Requiring-Endpoint"
+	headerRequiringMethod     = "X-Cb-Requiring-Method"
+	headerAlternativeEndpoint = "X-Cb-Alternative-Endpoint"
+)
+
+func decodeProxyRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if r.Method == http.MethodConnect {
+		return nil, util.ErrProxyConnectUnsupported
+	}
+
+	// a forward proxy receives the absolute URL, a reverse proxy only the path
+	target := *r.URL
+	if !target.IsAbs() {
+		target.Scheme = util.GetEnv("CB_PROXY_UPSTREAM_SCHEME", "http")
+		target.Host = r.Host
+	}
+
+	header := r.Header.Clone()
+	util.RemoveHopByHopHeaders(header)
+
+	requiringEndpoint := header.Get(headerRequiringEndpoint)
+	if requiringEndpoint == "" {
+		requiringEndpoint = util.GetEnv("CB_PROXY_REQUIRING_ENDPOINT", "")
+	}
+	requiringMethod := header.Get(headerRequiringMethod)
+	if requiringMethod == "" {
+		requiringMethod = util.GetEnv("CB_PROXY_REQUIRING_METHOD", "")
+	}
+	header.Del(headerRequiringEndpoint)
+	header.Del(headerRequiringMethod)
+
+	// the body is streamed to the upstream endpoint instead of being buffered, the headers keep all their values
+	return &service.StreamRequest{
+		Method:            r.Method,
+		URL:               target.String(),
+		Headers:           header,
+		Body:              r.Body,
+		RequiringEndpoint: requiringEndpoint,
+		RequiringMethod:   requiringMethod,
+	}, nil
+}
+
+func encodeProxyResponse(_ context.Context, w http.ResponseWriter, r interface{}) error {
+	res := r.(*service.Response)
+	if res.BodyStream != nil {
+		defer res.BodyStream.Close()
+	}
+
+	header := w.Header()
+	if res.Headers != nil {
+		for k, v := range res.Headers {
+			header[k] = append([]string(nil), v...)
+		}
+	} else {
+		for k, v := range res.Header {
+			header.Set(k, v)
+		}
+	}
+	util.RemoveHopByHopHeaders(header)
+	// the body is sent chunked so that the trailers can follow it, net/http sets the length of a short body
+	header.Del("Content-Length")
+
+	if res.IsFromAlternativeEndpoint {
+		header.Set(headerAlternativeEndpoint, "true")
+	}
+
+	statusCode := int(res.StatusCode)
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	w.WriteHeader(statusCode)
+
+	if res.BodyStream != nil {
+		// the trailers of a streamed response are only set once its body is read
+		if _, err := io.Copy(w, res.BodyStream); err != nil {
+			return err
+		}
+	} else if _, err := w.Write(res.Body); err != nil {
+		return err
+	}
+
+	for k, v := range res.Trailers {
+		header[http.TrailerPrefix+k] = append([]string(nil), v...)
+	}
+	return nil
+}
+
+// encodeProxyError answers the errors of the service with the HTTP status a proxy would use for them
+func encodeProxyError(_ context.Context, err error, w http.ResponseWriter) {
+	statusCode := http.StatusBadGateway
+	message := err.Error()
+
+	if errors.Is(err, util.ErrProxyConnectUnsupported) {
+		statusCode = http.StatusMethodNotAllowed
+	} else if st, ok := status.FromError(err); ok {
+		message = st.Message()
+		switch st.Code() {
+		case codes.Unavailable:
+			statusCode = http.StatusServiceUnavailable
+		case codes.FailedPrecondition, codes.InvalidArgument:
+			statusCode = http.StatusBadRequest
+		}
+	}
+
+	http.Error(w, message, statusCode)
+}
@@ -0,0 +1,168 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/daffarg/distributed-cascading-cb/service"
+	"github.com/daffarg/distributed-cascading-cb/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_decodeProxyRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		host   string
+		want   *service.StreamRequest
+	}{
+		{
+			name:   "Forward_proxy",
+			target: "http://localhost:8081/hello?name=a",
+			want: &service.StreamRequest{
+				Method:            http.MethodPost,
+				URL:               "http://localhost:8081/hello?name=a",
+				Headers:           http.Header{"Content-Type": {"text/plain"}, "Accept": {"text/plain", "application/json"}},
+				RequiringEndpoint: "http://localhost:8080/hello",
+				RequiringMethod:   "GET",
+			},
+		},
+		{
+			name:   "Reverse_proxy",
+			target: "/hello?name=a",
+			host:   "service-b:8081",
+			want: &service.StreamRequest{
+				Method:            http.MethodPost,
+				URL:               "http://service-b:8081/hello?name=a",
+				Headers:           http.Header{"Content-Type": {"text/plain"}, "Accept": {"text/plain", "application/json"}},
+				RequiringEndpoint: "http://localhost:8080/hello",
+				RequiringMethod:   "GET",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader("body"))
+			if tt.host != "" {
+				r.Host = tt.host
+			}
+			r.Header.Set("Content-Type", "text/plain")
+			r.Header.Add("Accept", "text/plain")
+			r.Header.Add("Accept", "application/json")
+			r.Header.Set("Connection", "keep-alive, X-Hop")
+			r.Header.Set("X-Hop", "1")
+			r.Header.Set("Proxy-Authorization", "Basic Zm9vOmJhcg==")
+			r.Header.Set(headerRequiringEndpoint, "http://localhost:8080/hello")
+			r.Header.Set(headerRequiringMethod, "GET")
+
+			got, err := decodeProxyRequest(context.Background(), r)
+			if err != nil {
+				t.Fatalf("decodeProxyRequest() error = %v", err)
+			}
+
+			req := got.(*service.StreamRequest)
+			body, err := io.ReadAll(req.Body)
+			if err != nil || string(body) != "body" {
+				t.Errorf("decodeProxyRequest() body = %q, %v, want %q", body, err, "body")
+			}
+			req.Body = nil
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeProxyRequest() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_encodeProxyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "Circuit_breaker_open", err: status.Error(codes.Unavailable, util.ErrCircuitBreakerOpen.Error()), want: http.StatusServiceUnavailable},
+		{name: "Failed_precondition", err: status.Error(codes.FailedPrecondition, "requiring_endpoint is required"), want: http.StatusBadRequest},
+		{name: "Failed_request", err: status.Error(codes.Internal, util.ErrFailedExecuteRequest.Error()), want: http.StatusBadGateway},
+		{name: "Connect", err: util.ErrProxyConnectUnsupported, want: http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			encodeProxyError(context.Background(), tt.err, w)
+			if w.Code != tt.want {
+				t.Errorf("encodeProxyError() status = %v, want %v", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func Test_encodeProxyResponse(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := encodeProxyResponse(context.Background(), w, &service.Response{
+		StatusCode: http.StatusOK,
+		Header:     map[string]string{"Set-Cookie": "a=1"},
+		Headers: http.Header{
+			"Set-Cookie": {"a=1", "b=2"},
+			"Connection": {"keep-alive"},
+		},
+		Trailers: http.Header{"Grpc-Status": {"0"}},
+		Body:     []byte("body"),
+	})
+	if err != nil {
+		t.Fatalf("encodeProxyResponse() error = %v", err)
+	}
+
+	res := w.Result()
+	if got, want := res.Header.Values("Set-Cookie"), []string{"a=1", "b=2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Set-Cookie = %v, want %v", got, want)
+	}
+	if got := res.Header.Get("Connection"); got != "" {
+		t.Errorf("Connection = %v, want it removed", got)
+	}
+	if got, want := res.Trailer.Get("Grpc-Status"), "0"; got != want {
+		t.Errorf("Grpc-Status trailer = %v, want %v", got, want)
+	}
+}
+
+func Test_encodeProxyResponse_stream(t *testing.T) {
+	res := &service.Response{
+		StatusCode: http.StatusOK,
+		Headers:    http.Header{"Content-Type": {"text/plain"}, "Content-Length": {"4"}},
+	}
+	body := io.NopCloser(strings.NewReader("body"))
+	res.BodyStream = &trailerSettingReader{ReadCloser: body, res: res, trailers: http.Header{"Checksum": {"abc"}}}
+
+	w := httptest.NewRecorder()
+	err := encodeProxyResponse(context.Background(), w, res)
+	if err != nil {
+		t.Fatalf("encodeProxyResponse() error = %v", err)
+	}
+
+	got := w.Result()
+	if got, want := w.Body.String(), "body"; got != want {
+		t.Errorf("body = %v, want %v", got, want)
+	}
+	if got, want := got.Trailer.Get("Checksum"), "abc"; got != want {
+		t.Errorf("Checksum trailer = %v, want %v", got, want)
+	}
+}
+
+// trailerSettingReader sets the trailers of the response at the end of its body like a streamed upstream response
+type trailerSettingReader struct {
+	io.ReadCloser
+	res      *service.Response
+	trailers http.Header
+}
+
+func (r *trailerSettingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if err == io.EOF {
+		r.res.Trailers = r.trailers
+	}
+	return n, err
+}
@@ -2,6 +2,7 @@ package transport
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/daffarg/distributed-cascading-cb/protobuf"
 	"github.com/daffarg/distributed-cascading-cb/service"
@@ -66,17 +67,49 @@ func decodeDeleteRequest(_ context.Context, r interface{}) (interface{}, error)
 	}, nil
 }
 
+func decodeWatchStatusRequest(_ context.Context, r interface{}) (interface{}, error) {
+	pbReq := r.(*protobuf.WatchStatusRequest)
+
+	endpoints := make([]*service.BreakerRequest, 0, len(pbReq.Endpoints))
+	for _, ep := range pbReq.Endpoints {
+		endpoints = append(endpoints, &service.BreakerRequest{
+			Endpoint: ep.Endpoint,
+			Method:   ep.Method,
+		})
+	}
+
+	return &service.WatchStatusRequest{
+		Endpoints: endpoints,
+	}, nil
+}
+
 func encodeResponse(_ context.Context, r interface{}) (interface{}, error) {
 	res := r.(*service.Response)
 
 	return &protobuf.Response{
-		Status:        res.Status,
-		StatusCode:    res.StatusCode,
-		Body:          res.Body,
-		Header:        res.Header,
-		Proto:         res.Proto,
-		ProtoMajor:    res.ProtoMajor,
-		ProtoMinor:    res.ProtoMinor,
-		ContentLength: res.ContentLength,
+		Status:                    res.Status,
+		StatusCode:                res.StatusCode,
+		Body:                      res.Body,
+		Header:                    res.Header,
+		Proto:                     res.Proto,
+		ProtoMajor:                res.ProtoMajor,
+		ProtoMinor:                res.ProtoMinor,
+		ContentLength:             res.ContentLength,
+		IsFromAlternativeEndpoint: res.IsFromAlternativeEndpoint,
+		IsFailure:                 res.IsFailure,
+		Headers:                   encodeHeaderValues(res.Headers),
+		Trailers:                  encodeHeaderValues(res.Trailers),
 	}, nil
 }
+
+func encodeHeaderValues(header http.Header) map[string]*protobuf.HeaderValues {
+	if header == nil {
+		return nil
+	}
+
+	headerValues := make(map[string]*protobuf.HeaderValues, len(header))
+	for k, v := range header {
+		headerValues[k] = &protobuf.HeaderValues{Values: v}
+	}
+	return headerValues
+}
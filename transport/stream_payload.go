@@ -0,0 +1,130 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+
+	"github.com/daffarg/distributed-cascading-cb/protobuf"
+	"github.com/daffarg/distributed-cascading-cb/service"
+	"github.com/daffarg/distributed-cascading-cb/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// decodeStreamRequest reads the header of a streamed request, its body is read from the stream by the service
+func decodeStreamRequest(recv func() (*protobuf.StreamRequest, error)) (*service.StreamRequest, error) {
+	msg, err := recv()
+	if errors.Is(err, io.EOF) || (err == nil && msg.GetHeader() == nil) {
+		return nil, status.Error(codes.InvalidArgument, util.ErrMissingStreamHeader.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeGeneralStreamRequest(msg.GetHeader(), &streamBodyReader{recv: recv})
+}
+
+func decodeGeneralStreamRequest(pbReq *protobuf.GeneralRequest, body io.Reader) (*service.StreamRequest, error) {
+	reqBody := io.Reader(bytes.NewReader(pbReq.Body))
+	if body != nil {
+		reqBody = io.MultiReader(reqBody, body)
+	}
+
+	return &service.StreamRequest{
+		Method:            pbReq.Method,
+		URL:               pbReq.Url,
+		Header:            pbReq.Header,
+		Body:              reqBody,
+		RequiringEndpoint: pbReq.RequiringEndpoint,
+		RequiringMethod:   pbReq.RequiringMethod,
+	}, nil
+}
+
+// streamBodyReader reads a body from the chunks of the messages of a stream until the stream ends
+type streamBodyReader struct {
+	recv  func() (*protobuf.StreamRequest, error)
+	chunk []byte
+}
+
+func (r *streamBodyReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		msg, err := r.recv()
+		if err != nil {
+			return 0, err
+		}
+
+		part, ok := msg.Part.(*protobuf.StreamRequest_Chunk)
+		if !ok {
+			return 0, status.Error(codes.InvalidArgument, util.ErrUnexpectedStreamHeader.Error())
+		}
+		r.chunk = part.Chunk
+	}
+
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
+
+// encodeStreamResponse sends the response without its body, then its body in chunks and its trailers last
+func encodeStreamResponse(ctx context.Context, res *service.Response, send func(*protobuf.StreamResponse) error) error {
+	body := res.BodyStream
+	if body == nil {
+		body = io.NopCloser(bytes.NewReader(res.Body))
+	}
+	defer body.Close()
+
+	header, err := encodeResponse(ctx, res)
+	if err != nil {
+		return err
+	}
+	if err := send(&protobuf.StreamResponse{Part: &protobuf.StreamResponse_Header{Header: header.(*protobuf.Response)}}); err != nil {
+		return err
+	}
+
+	chunk := make([]byte, util.StreamChunkSize)
+	for {
+		n, err := body.Read(chunk)
+		if n > 0 {
+			if err := send(&protobuf.StreamResponse{Part: &protobuf.StreamResponse_Chunk{Chunk: chunk[:n]}}); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return status.Error(codes.Unavailable, err.Error())
+		}
+	}
+
+	if len(res.Trailers) == 0 {
+		return nil
+	}
+	return send(&protobuf.StreamResponse{Part: &protobuf.StreamResponse_Trailers{
+		Trailers: &protobuf.ResponseTrailers{Trailers: encodeHeaderValues(res.Trailers)},
+	}})
+}
+
+// encodeBufferedStreamResponse reads the body of a streamed response up to the maximum buffered size
+func encodeBufferedStreamResponse(ctx context.Context, res *service.Response) (*protobuf.Response, error) {
+	if res.BodyStream != nil {
+		defer res.BodyStream.Close()
+
+		body, err := util.ReadAllLimited(res.BodyStream, util.GetMaxBufferedBodySize())
+		if errors.Is(err, util.ErrBodyTooLarge) {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		if err != nil {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		res.Body = body
+	}
+
+	pbRes, err := encodeResponse(ctx, res)
+	if err != nil {
+		return nil, err
+	}
+	return pbRes.(*protobuf.Response), nil
+}
@@ -0,0 +1,100 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/daffarg/distributed-cascading-cb/protobuf"
+	"github.com/daffarg/distributed-cascading-cb/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_decodeStreamRequest(t *testing.T) {
+	header := &protobuf.StreamRequest{Part: &protobuf.StreamRequest_Header{Header: &protobuf.GeneralRequest{
+		Method:            http.MethodPost,
+		Url:               "http://localhost:8081/upload",
+		Body:              []byte("he"),
+		RequiringEndpoint: "http://localhost:8080/upload",
+		RequiringMethod:   http.MethodPost,
+	}}}
+	chunk := func(body string) *protobuf.StreamRequest {
+		return &protobuf.StreamRequest{Part: &protobuf.StreamRequest_Chunk{Chunk: []byte(body)}}
+	}
+
+	tests := []struct {
+		name     string
+		msgs     []*protobuf.StreamRequest
+		wantCode codes.Code
+		wantBody string
+	}{
+		{name: "Chunked_body", msgs: []*protobuf.StreamRequest{header, chunk("ll"), chunk("o")}, wantCode: codes.OK, wantBody: "hello"},
+		{name: "Missing_header", msgs: []*protobuf.StreamRequest{chunk("hello")}, wantCode: codes.InvalidArgument},
+		{name: "Empty_stream", wantCode: codes.InvalidArgument},
+		{name: "Header_after_chunk", msgs: []*protobuf.StreamRequest{header, chunk("ll"), header}, wantCode: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msgs := tt.msgs
+			recv := func() (*protobuf.StreamRequest, error) {
+				if len(msgs) == 0 {
+					return nil, io.EOF
+				}
+				msg := msgs[0]
+				msgs = msgs[1:]
+				return msg, nil
+			}
+
+			req, err := decodeStreamRequest(recv)
+			var body []byte
+			if err == nil {
+				body, err = io.ReadAll(req.Body)
+			}
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("decodeStreamRequest() error = %v, want code %v", err, tt.wantCode)
+			}
+			if err == nil && string(body) != tt.wantBody {
+				t.Errorf("body = %v, want %v", string(body), tt.wantBody)
+			}
+		})
+	}
+}
+
+func Test_encodeStreamResponse(t *testing.T) {
+	res := &service.Response{
+		StatusCode: http.StatusOK,
+		Headers:    http.Header{"Content-Type": {"application/octet-stream"}},
+		Trailers:   http.Header{"X-Checksum": {"abc"}},
+		BodyStream: io.NopCloser(strings.NewReader(strings.Repeat("a", 40<<10))),
+	}
+
+	var msgs []*protobuf.StreamResponse
+	err := encodeStreamResponse(context.Background(), res, func(msg *protobuf.StreamResponse) error {
+		// the chunk buffer is reused, the message is copied like gRPC marshals it before Send returns
+		if chunk, ok := msg.Part.(*protobuf.StreamResponse_Chunk); ok {
+			msg = &protobuf.StreamResponse{Part: &protobuf.StreamResponse_Chunk{Chunk: append([]byte(nil), chunk.Chunk...)}}
+		}
+		msgs = append(msgs, msg)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("encodeStreamResponse() error = %v", err)
+	}
+
+	if len(msgs) != 4 {
+		t.Fatalf("messages = %v, want the header, 2 chunks and the trailers", len(msgs))
+	}
+	if got := msgs[0].GetHeader().GetStatusCode(); got != http.StatusOK {
+		t.Errorf("header status code = %v, want %v", got, http.StatusOK)
+	}
+	if got := len(msgs[1].GetChunk()) + len(msgs[2].GetChunk()); got != 40<<10 {
+		t.Errorf("body length = %v, want %v", got, 40<<10)
+	}
+	if got, want := msgs[3].GetTrailers().GetTrailers()["X-Checksum"].GetValues(), []string{"abc"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("trailers = %v, want %v", got, want)
+	}
+}
@@ -19,11 +19,28 @@ const (
 	LogConfig                  = "config"
 	LogKey                     = "key"
 	LogEvent                   = "event"
+	LogMetricsOnly             = "metrics_only"
+	LogRequiringEndpoint       = "requiring_endpoint"
+	LogRootCauseEndpoint       = "root_cause_endpoint"
 )
 
+// DefaultMaxBufferedBodySize is the default maximum size of the request and response bodies of the unary RPCs,
+// the default maximum message size of gRPC
+const DefaultMaxBufferedBodySize = 4 << 20
+
+// StreamChunkSize is the size of the body chunks sent by the streaming RPCs
+const StreamChunkSize = 32 << 10
+
 const (
 	RequiringsEndpointKeyPrefix = "requirings:"
 	StatusKeyPrefix             = "status:"
+	RootCauseKeyPrefix          = "rootcause:"
+)
+
+// baggage members a service sets on its outgoing requests to name the endpoint making them
+const (
+	BaggageRequiringEndpoint = "cb.requiring_endpoint"
+	BaggageRequiringMethod   = "cb.requiring_method"
 )
 
 const (
@@ -9,4 +9,12 @@ var (
 	ErrFailedExecuteRequest     = errors.New("failed to execute the request")
 	ErrFailedExecuteAltEndpoint = errors.New("failed to execute the request to the alternative endpoint")
 	ErrUpdatedStatusNotFound    = errors.New("circuit breaker updated status not found")
+	ErrBreakerNotFound          = errors.New("circuit breaker not found")
+	ErrWrongType                = errors.New("operation against a key holding the wrong kind of value")
+	ErrUnknownGraphFormat       = errors.New("unknown graph format")
+	ErrProxyConnectUnsupported  = errors.New("CONNECT is not supported by the proxy")
+	ErrBodyTooLarge             = errors.New("body is larger than the maximum buffered size, use the streaming RPCs")
+	ErrBodyAlreadySent          = errors.New("streamed body was already sent")
+	ErrMissingStreamHeader      = errors.New("first message of the stream must be the header")
+	ErrUnexpectedStreamHeader   = errors.New("header can only be sent in the first message of the stream")
 )
@@ -3,6 +3,8 @@ package util
 import (
 	"fmt"
 	"github.com/btcsuite/btcd/btcutil/base58"
+	"io"
+	"net/http"
 	"net/url"
 	"os"
 	"strconv"
@@ -24,6 +26,33 @@ func GetIntEnv(key string, fallback int) int {
 	return valueAsInt
 }
 
+func GetBoolEnv(key string, fallback bool) bool {
+	value := GetEnv(key, strconv.FormatBool(fallback))
+	valueAsBool, err := strconv.ParseBool(value)
+	if err != nil {
+		return fallback
+	}
+
+	return valueAsBool
+}
+
+// GetMaxBufferedBodySize returns the maximum size of a body buffered in memory by the unary RPCs
+func GetMaxBufferedBodySize() int64 {
+	return int64(GetIntEnv("CB_MAX_BUFFERED_BODY_SIZE", DefaultMaxBufferedBodySize))
+}
+
+// ReadAllLimited reads r like io.ReadAll but fails with ErrBodyTooLarge when r is longer than limit
+func ReadAllLimited(r io.Reader, limit int64) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, ErrBodyTooLarge
+	}
+	return body, nil
+}
+
 func GetGeneralURLFormat(urlStr string) (string, error) {
 	parsedUrl, err := url.Parse(urlStr)
 	if err != nil {
@@ -48,6 +77,10 @@ func FormEndpointStatusKey(endpointName string) string {
 	return fmt.Sprintf("%s%s", StatusKeyPrefix, endpointName)
 }
 
+func FormRootCauseKey(endpointName string) string {
+	return fmt.Sprintf("%s%s", RootCauseKeyPrefix, endpointName)
+}
+
 func FormRequiringEndpointsKey(endpointName string) string {
 	return fmt.Sprintf("%s%s", RequiringsEndpointKeyPrefix, endpointName)
 }
@@ -56,6 +89,10 @@ func EncodeTopic(topic string) string {
 	return base58.Encode([]byte(topic))
 }
 
+func GetEndpointFromStatusKey(key string) string {
+	return strings.TrimPrefix(key, StatusKeyPrefix)
+}
+
 func GetEndpointFromRequiringsKey(key string) string {
 	colonIndex := strings.Index(key, ":")
 	if colonIndex == -1 {
@@ -64,3 +101,29 @@ func GetEndpointFromRequiringsKey(key string) string {
 
 	return key[colonIndex+1:]
 }
+
+// HopByHopHeaders only apply to a single connection and are not forwarded by a proxy, see RFC 9110 section 7.6.1
+var HopByHopHeaders = []string{
+	"Connection",
+	"Proxy-Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// RemoveHopByHopHeaders removes the HopByHopHeaders and the headers listed in the Connection header
+func RemoveHopByHopHeaders(header http.Header) {
+	for _, value := range header.Values("Connection") {
+		for _, name := range strings.Split(value, ",") {
+			header.Del(strings.TrimSpace(name))
+		}
+	}
+
+	for _, name := range HopByHopHeaders {
+		header.Del(name)
+	}
+}